@@ -0,0 +1,274 @@
+package booleans
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+
+	"github.com/cramanan/go-types/functions"
+	"github.com/cramanan/go-types/slices"
+)
+
+const wordSize = 64
+
+// Bitset is a fixed-size set of flags packed into 64-bit words.
+// It stores one bit per flag where a []Boolean would use a whole byte.
+//
+// Bit indices go from 0 to Len()-1. Accessing a bit outside of this range panics.
+type Bitset struct {
+	words []uint64
+	size  int
+}
+
+// NewBitset returns a Bitset of the given size with every bit cleared.
+func NewBitset(size int) Bitset {
+	if size < 0 {
+		panic("bitset size is negative")
+	}
+	return Bitset{words: make([]uint64, (size+wordSize-1)/wordSize), size: size}
+}
+
+// BitsetFrom converts a slice of booleans into a Bitset of the same length.
+// Bit i is set if s[i] is true.
+func BitsetFrom[S ~[]B, B ~bool](s S) Bitset {
+	b := NewBitset(len(s))
+	for i, v := range s {
+		if v {
+			b.words[i/wordSize] |= 1 << (i % wordSize)
+		}
+	}
+	return b
+}
+
+// Len returns the number of bits in the Bitset.
+func (b Bitset) Len() int { return b.size }
+
+// Set sets bit i to true.
+func (b *Bitset) Set(i int) { b.check(i); b.words[i/wordSize] |= 1 << (i % wordSize) }
+
+// Clear sets bit i to false.
+func (b *Bitset) Clear(i int) { b.check(i); b.words[i/wordSize] &^= 1 << (i % wordSize) }
+
+// Toggle flips the value of bit i.
+func (b *Bitset) Toggle(i int) { b.check(i); b.words[i/wordSize] ^= 1 << (i % wordSize) }
+
+// Test returns the value of bit i.
+func (b Bitset) Test(i int) Boolean {
+	b.check(i)
+	return b.words[i/wordSize]&(1<<(i%wordSize)) != 0
+}
+
+// Count returns the number of bits set to true.
+func (b Bitset) Count() (count int) {
+	for _, w := range b.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+// NextSet returns the index of the first set bit at or after i.
+// If there is none, found is false.
+func (b Bitset) NextSet(i int) (next int, found bool) {
+	if i < 0 {
+		i = 0
+	}
+	if i >= b.size {
+		return 0, false
+	}
+	w := i / wordSize
+	word := b.words[w] >> (i % wordSize)
+	if word != 0 {
+		return i + bits.TrailingZeros64(word), true
+	}
+	for w++; w < len(b.words); w++ {
+		if b.words[w] != 0 {
+			return w*wordSize + bits.TrailingZeros64(b.words[w]), true
+		}
+	}
+	return 0, false
+}
+
+// All returns an iterator over the indices of the set bits, in increasing order.
+func (b Bitset) All() functions.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// ForEach calls the provided callback function with the index of every set bit, in increasing order.
+func (b Bitset) ForEach(callbackFn func(index int)) {
+	if callbackFn == nil {
+		panic("callback function is nil")
+	}
+	b.All()(func(i int) bool {
+		callbackFn(i)
+		return true
+	})
+}
+
+// Slice converts the Bitset into a Slice of Boolean of the same length.
+func (b Bitset) Slice() slices.Slice[Boolean] {
+	s := make(slices.Slice[Boolean], b.size)
+	for i := range s {
+		s[i] = b.words[i/wordSize]&(1<<(i%wordSize)) != 0
+	}
+	return s
+}
+
+// Clone returns a copy of the Bitset.
+func (b Bitset) Clone() Bitset {
+	return Bitset{words: append([]uint64(nil), b.words...), size: b.size}
+}
+
+// Equal reports whether both Bitsets have the same length and the same bits set.
+func (b Bitset) Equal(b2 Bitset) bool {
+	if b.size != b2.size {
+		return false
+	}
+	for i, w := range b.words {
+		if w != b2.words[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the bits as a string of '0' and '1', starting with bit 0.
+func (b Bitset) String() string {
+	buf := make([]byte, b.size)
+	for i := range buf {
+		buf[i] = '0'
+		if b.words[i/wordSize]&(1<<(i%wordSize)) != 0 {
+			buf[i] = '1'
+		}
+	}
+	return string(buf)
+}
+
+// AND returns the bitwise conjunction of b and b2.
+//
+// Like the other bitwise operators, if the Bitsets have different lengths
+// the shorter one is padded with false and the result has the length of the longer one.
+func (b Bitset) AND(b2 Bitset) Bitset { return b.apply(b2, func(x, y uint64) uint64 { return x & y }) }
+
+// OR returns the bitwise disjunction of b and b2.
+func (b Bitset) OR(b2 Bitset) Bitset { return b.apply(b2, func(x, y uint64) uint64 { return x | y }) }
+
+// XOR returns the bitwise exclusive disjunction of b and b2.
+func (b Bitset) XOR(b2 Bitset) Bitset { return b.apply(b2, func(x, y uint64) uint64 { return x ^ y }) }
+
+// NAND returns the bitwise negation of the conjunction of b and b2.
+func (b Bitset) NAND(b2 Bitset) Bitset { return b.AND(b2).NOT() }
+
+// NOR returns the bitwise negation of the disjunction of b and b2.
+func (b Bitset) NOR(b2 Bitset) Bitset { return b.OR(b2).NOT() }
+
+// NOT returns the bitwise negation of b.
+func (b Bitset) NOT() Bitset {
+	not := b.Clone()
+	for i := range not.words {
+		not.words[i] = ^not.words[i]
+	}
+	not.trim()
+	return not
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The encoding is the length as an 8-byte little-endian integer
+// followed by each word in little-endian order.
+func (b Bitset) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 8+8*len(b.words))
+	binary.LittleEndian.PutUint64(data, uint64(b.size))
+	for i, w := range b.words {
+		binary.LittleEndian.PutUint64(data[8+8*i:], w)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (b *Bitset) UnmarshalBinary(data []byte) error {
+	if len(data) < 8 {
+		return errors.New("booleans: bitset data too short")
+	}
+	size := binary.LittleEndian.Uint64(data)
+	if size > math.MaxInt {
+		return errors.New("booleans: bitset size out of range")
+	}
+	words := size / wordSize
+	if size%wordSize != 0 {
+		words++
+	}
+	if uint64(len(data)-8) != 8*words {
+		return errors.New("booleans: bitset data length does not match its size")
+	}
+	decoded := NewBitset(int(size))
+	for i := range decoded.words {
+		decoded.words[i] = binary.LittleEndian.Uint64(data[8+8*i:])
+	}
+	decoded.trim()
+	*b = decoded
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The text form is the base64 encoding of [Bitset.MarshalBinary].
+func (b Bitset) MarshalText() ([]byte, error) {
+	data, err := b.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	text := make([]byte, base64.StdEncoding.EncodedLen(len(data)))
+	base64.StdEncoding.Encode(text, data)
+	return text, nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (b *Bitset) UnmarshalText(text []byte) error {
+	data := make([]byte, base64.StdEncoding.DecodedLen(len(text)))
+	n, err := base64.StdEncoding.Decode(data, text)
+	if err != nil {
+		return err
+	}
+	return b.UnmarshalBinary(data[:n])
+}
+
+// apply combines b and b2 word by word with op.
+func (b Bitset) apply(b2 Bitset, op func(x, y uint64) uint64) Bitset {
+	size := b.size
+	if b2.size > size {
+		size = b2.size
+	}
+	result := NewBitset(size)
+	for i := range result.words {
+		var x, y uint64
+		if i < len(b.words) {
+			x = b.words[i]
+		}
+		if i < len(b2.words) {
+			y = b2.words[i]
+		}
+		result.words[i] = op(x, y)
+	}
+	result.trim()
+	return result
+}
+
+// trim clears the unused bits of the last word.
+func (b *Bitset) trim() {
+	if extra := b.size % wordSize; extra != 0 {
+		b.words[len(b.words)-1] &= 1<<extra - 1
+	}
+}
+
+// check panics if i is not a valid bit index.
+func (b Bitset) check(i int) {
+	if i < 0 || i >= b.size {
+		panic("bit index out of range")
+	}
+}
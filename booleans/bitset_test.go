@@ -0,0 +1,146 @@
+package booleans_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/cramanan/go-types/booleans"
+	"github.com/cramanan/go-types/slices"
+)
+
+func TestBitset(t *testing.T) {
+	b := NewBitset(130)
+	b.Set(0)
+	b.Set(64)
+	b.Set(129)
+	b.Toggle(3)
+	b.Toggle(3)
+	b.Clear(0)
+
+	if got := b.Count(); got != 2 {
+		t.Errorf("Count() = %d, want %d", got, 2)
+	}
+	if !b.Test(64) || b.Test(0) || b.Test(3) {
+		t.Errorf("Test() got wrong values for %s", b)
+	}
+
+	var got []int
+	b.All()(func(i int) bool {
+		got = append(got, i)
+		return true
+	})
+	if want := []int{64, 129}; !slices.Equal(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+
+	if next, ok := b.NextSet(65); !ok || next != 129 {
+		t.Errorf("NextSet(65) = %d, %t, want %d, %t", next, ok, 129, true)
+	}
+	if _, ok := b.NextSet(130); ok {
+		t.Error("NextSet(130) found a bit past the end")
+	}
+
+	defer func() {
+		if reason := recover(); reason != "bit index out of range" {
+			t.Error("Should have panicked but didn't")
+		}
+	}()
+	b.Set(130)
+}
+
+func TestBitsetOperators(t *testing.T) {
+	x := BitsetFrom([]bool{true, true, false, false})
+	y := BitsetFrom([]Boolean{True, False, True, False})
+
+	testCases := []struct {
+		desc      string
+		got, want string
+	}{
+		{"AND", x.AND(y).String(), "1000"},
+		{"OR", x.OR(y).String(), "1110"},
+		{"XOR", x.XOR(y).String(), "0110"},
+		{"NAND", x.NAND(y).String(), "0111"},
+		{"NOR", x.NOR(y).String(), "0001"},
+		{"NOT", x.NOT().String(), "0011"},
+		{"Mixed lengths", x.OR(BitsetFrom([]bool{false, false, false, false, true})).String(), "11001"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if tC.got != tC.want {
+				t.Errorf("%s got %s, want %s", tC.desc, tC.got, tC.want)
+			}
+		})
+	}
+
+	// Each bit must match the Boolean operator applied to the same flags.
+	xs, ys := x.Slice(), y.Slice()
+	and := x.AND(y).Slice()
+	for i := range xs {
+		if and[i] != xs[i].AND(ys[i]) {
+			t.Errorf("AND bit %d = %t, want %t", i, and[i], xs[i].AND(ys[i]))
+		}
+	}
+}
+
+func TestBitsetSerialisation(t *testing.T) {
+	b := NewBitset(70)
+	b.Set(1)
+	b.Set(69)
+
+	data, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Bitset
+	if err = decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equal(b) {
+		t.Errorf("UnmarshalBinary() = %s, want %s", decoded, b)
+	}
+
+	text, err := json.Marshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded = Bitset{}
+	if err = json.Unmarshal(text, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equal(b) {
+		t.Errorf("json round trip = %s, want %s", decoded, b)
+	}
+
+	if err = decoded.UnmarshalBinary(data[:12]); err == nil {
+		t.Error("UnmarshalBinary() accepted truncated data")
+	}
+
+	malformed := [][]byte{
+		{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		{0xc1, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f},
+		{0x01, 0, 0, 0, 0, 0, 0, 0},
+	}
+	for _, data := range malformed {
+		if err = decoded.UnmarshalBinary(data); err == nil {
+			t.Errorf("UnmarshalBinary(%x) accepted malformed data", data)
+		}
+	}
+	if err = decoded.UnmarshalText([]byte("//////////8=")); err == nil {
+		t.Error("UnmarshalText() accepted malformed data")
+	}
+}
+
+var sink int
+
+func BenchmarkBitsetCount(b *testing.B) {
+	s := make([]bool, 1<<16)
+	for i := range s {
+		s[i] = i%3 == 0
+	}
+	bs := BitsetFrom(s)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sink = bs.Count()
+	}
+}
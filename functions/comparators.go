@@ -0,0 +1,123 @@
+package functions
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/exp/constraints"
+)
+
+// By returns a ComparisonFunc ordering values of type T by the key returned by keyFn, in ascending order.
+//
+// Example:
+//
+//	type User struct {
+//		Name string
+//		Age  int
+//	}
+//
+//	byAge := By(func(u User) int { return u.Age })
+//	users.SortFunc(ThenBy(byAge, func(u User) string { return u.Name }))
+func By[T any, K constraints.Ordered](keyFn func(T) K) ComparisonFunc[T] {
+	if keyFn == nil {
+		panic("callback function is nil")
+	}
+	return func(x, y T) int { return Compare(keyFn(x), keyFn(y)) }
+}
+
+// ThenBy returns a ComparisonFunc that orders values with cmp,
+// then breaks ties by the key returned by keyFn, in ascending order.
+func ThenBy[T any, K constraints.Ordered](cmp ComparisonFunc[T], keyFn func(T) K) ComparisonFunc[T] {
+	return cmp.Then(By(keyFn))
+}
+
+// ThenByDesc is like [ThenBy] but breaks ties by the key in descending order.
+func ThenByDesc[T any, K constraints.Ordered](cmp ComparisonFunc[T], keyFn func(T) K) ComparisonFunc[T] {
+	return cmp.Then(By(keyFn).Reversed())
+}
+
+// Then returns a ComparisonFunc that orders values with cmp and uses next to break ties.
+func (cmp ComparisonFunc[T]) Then(next ComparisonFunc[T]) ComparisonFunc[T] {
+	if cmp == nil || next == nil {
+		panic("callback function is nil")
+	}
+	return func(x, y T) int {
+		if order := cmp(x, y); order != 0 {
+			return order
+		}
+		return next(x, y)
+	}
+}
+
+// Reversed returns a ComparisonFunc that orders values in the opposite order of cmp.
+func (cmp ComparisonFunc[T]) Reversed() ComparisonFunc[T] {
+	if cmp == nil {
+		panic("callback function is nil")
+	}
+	return func(x, y T) int { return cmp(y, x) }
+}
+
+// NilsFirst returns a ComparisonFunc for pointers that places nil pointers before any other pointer
+// and orders non-nil pointers by comparing the values they point to with cmp.
+func NilsFirst[T any](cmp ComparisonFunc[T]) ComparisonFunc[*T] { return nils(cmp, -1) }
+
+// NilsLast is like [NilsFirst] but places nil pointers after any other pointer.
+func NilsLast[T any](cmp ComparisonFunc[T]) ComparisonFunc[*T] { return nils(cmp, +1) }
+
+// nils orders nil pointers by returning order when only x is nil.
+func nils[T any](cmp ComparisonFunc[T], order int) ComparisonFunc[*T] {
+	if cmp == nil {
+		panic("callback function is nil")
+	}
+	return func(x, y *T) int {
+		switch {
+		case x == nil && y == nil:
+			return 0
+		case x == nil:
+			return order
+		case y == nil:
+			return -order
+		}
+		return cmp(*x, *y)
+	}
+}
+
+// CaseInsensitive compares two strings rune by rune after converting each rune to lower case.
+// It works with any string type, including strings.String.
+//
+// Example:
+//
+//	s := slices.Slice[strings.String]{"b", "A", "c"}
+//	s.SortFunc(CaseInsensitive[strings.String]) // s is now [A b c]
+func CaseInsensitive[S ~string](x, y S) int {
+	for len(x) > 0 && len(y) > 0 {
+		rx, nx := utf8.DecodeRuneInString(string(x))
+		ry, ny := utf8.DecodeRuneInString(string(y))
+		if order := Compare(unicode.ToLower(rx), unicode.ToLower(ry)); order != 0 {
+			return order
+		}
+		x, y = x[nx:], y[ny:]
+	}
+	return Compare(len(x), len(y))
+}
+
+// Lexicographic returns a ComparisonFunc for slices that compares elements pairwise with cmp.
+// The result is the first non-zero result of cmp; if every pair is equal,
+// the shorter slice is ordered first.
+//
+// The slice type is the first type parameter so it can be given explicitly:
+//
+//	Lexicographic[slices.Slice[int]](Ascending[int])
+func Lexicographic[S ~[]T, T any](cmp ComparisonFunc[T]) ComparisonFunc[S] {
+	if cmp == nil {
+		panic("callback function is nil")
+	}
+	return func(x, y S) int {
+		for i := 0; i < len(x) && i < len(y); i++ {
+			if order := cmp(x[i], y[i]); order != 0 {
+				return order
+			}
+		}
+		return Compare(len(x), len(y))
+	}
+}
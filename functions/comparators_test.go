@@ -0,0 +1,114 @@
+package functions_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/cramanan/go-types/functions"
+	"github.com/cramanan/go-types/slices"
+	"github.com/cramanan/go-types/slices/ordered"
+	"github.com/cramanan/go-types/strings"
+)
+
+type employee struct {
+	Team string
+	Name string
+	Age  int
+}
+
+func TestComparators(t *testing.T) {
+	team := func(e employee) string { return e.Team }
+	name := func(e employee) string { return e.Name }
+	age := func(e employee) int { return e.Age }
+
+	employees := slices.Slice[employee]{
+		{"ops", "Jane", 30},
+		{"dev", "Bob", 25},
+		{"ops", "Alice", 41},
+		{"dev", "Carl", 25},
+		{"dev", "Abel", 33},
+	}
+
+	employees.SortStableFunc(ThenBy(ThenByDesc(By(team), age), name))
+	want := slices.Slice[employee]{
+		{"dev", "Abel", 33},
+		{"dev", "Bob", 25},
+		{"dev", "Carl", 25},
+		{"ops", "Alice", 41},
+		{"ops", "Jane", 30},
+	}
+	if !reflect.DeepEqual(employees, want) {
+		t.Errorf("ThenBy/ThenByDesc sort = %v, want %v", employees, want)
+	}
+
+	employees.SortFunc(By(age).Reversed().Then(By(name)))
+	if got := employees[0].Name; got != "Alice" {
+		t.Errorf("Reversed().Then() first = %s, want %s", got, "Alice")
+	}
+	if got := employees[3].Name; got != "Bob" {
+		t.Errorf("Reversed().Then() tie-break = %s, want %s", got, "Bob")
+	}
+
+	o := ordered.New(3, 1, 2)
+	o.SortFunc(ComparisonFunc[int](Ascending[int]).Reversed())
+	if want := ordered.New(3, 2, 1); !reflect.DeepEqual(o, want) {
+		t.Errorf("Ordered.SortFunc(Reversed) = %v, want %v", o, want)
+	}
+}
+
+func TestNilsFirstLast(t *testing.T) {
+	one, two := 1, 2
+	ptrs := []*int{&two, nil, &one}
+
+	slices.SortFunc(ptrs, NilsFirst[int](Ascending[int]))
+	if ptrs[0] != nil || *ptrs[1] != 1 || *ptrs[2] != 2 {
+		t.Errorf("NilsFirst order = %v, %v, %v", ptrs[0], ptrs[1], ptrs[2])
+	}
+
+	slices.SortFunc(ptrs, NilsLast[int](Ascending[int]))
+	if *ptrs[0] != 1 || *ptrs[1] != 2 || ptrs[2] != nil {
+		t.Errorf("NilsLast order = %v, %v, %v", ptrs[0], ptrs[1], ptrs[2])
+	}
+}
+
+func TestCaseInsensitive(t *testing.T) {
+	testCases := []struct {
+		x, y strings.String
+		want int
+	}{
+		{"abc", "ABC", 0},
+		{"abc", "ABD", -1},
+		{"Zeta", "alpha", 1},
+		{"ab", "ABC", -1},
+		{"Éclair", "éclair", 0},
+	}
+	for _, tC := range testCases {
+		if got := CaseInsensitive(tC.x, tC.y); got != tC.want {
+			t.Errorf("CaseInsensitive(%q, %q) = %d, want %d", tC.x, tC.y, got, tC.want)
+		}
+	}
+
+	words := slices.Slice[strings.String]{"banana", "Apple", "cherry"}
+	words.SortFunc(CaseInsensitive[strings.String])
+	if want := (slices.Slice[strings.String]{"Apple", "banana", "cherry"}); !reflect.DeepEqual(words, want) {
+		t.Errorf("SortFunc(CaseInsensitive) = %v, want %v", words, want)
+	}
+}
+
+func TestLexicographic(t *testing.T) {
+	cmp := Lexicographic[slices.Slice[int]](Ascending[int])
+	testCases := []struct {
+		x, y slices.Slice[int]
+		want int
+	}{
+		{slices.New(1, 2), slices.New(1, 2), 0},
+		{slices.New(1, 2), slices.New(1, 3), -1},
+		{slices.New(1, 2, 0), slices.New(1, 2), 1},
+		{nil, slices.New(0), -1},
+	}
+	for _, tC := range testCases {
+		if got := cmp(tC.x, tC.y); got != tC.want {
+			t.Errorf("Lexicographic(%v, %v) = %d, want %d", tC.x, tC.y, got, tC.want)
+		}
+	}
+}
@@ -18,3 +18,14 @@ type CallbackFunc[T any] func(T, int)
 type SatisfyFunc[T comparable] func(T) func(T) bool
 
 type CallbackFuncReturns[O, I any] func(I, int) O
+
+// Seq is an iterator over a sequence of values of type T.
+// The sequence calls yield for each value in turn and stops early as soon as yield returns false.
+//
+// Seq has the same shape as the standard iter.Seq, so it can be ranged over
+// directly with Go 1.23 and later.
+type Seq[T any] func(yield func(T) bool)
+
+// Seq2 is an iterator over a sequence of pairs of values.
+// It behaves like Seq but yields a key and a value on each step.
+type Seq2[K, V any] func(yield func(K, V) bool)
@@ -0,0 +1,196 @@
+package maps
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrConflict is returned by [BiMap.Put] when an entry would break the one-to-one mapping.
+var ErrConflict = errors.New("maps: conflicting entry")
+
+// ConflictPolicy defines what [BiMap.Put] does when the key or the value is already bound.
+type ConflictPolicy int
+
+const (
+	// RejectConflicts makes Put fail if the key is bound to another value or the value to another key.
+	RejectConflicts ConflictPolicy = iota
+
+	// OverwriteKey makes Put replace the value of a key that is already present,
+	// but fail if the value is bound to another key.
+	OverwriteKey
+
+	// ForcePut makes Put remove any entry bound to the key or to the value before adding the new entry.
+	ForcePut
+)
+
+// BiMap is a one-to-one map: every key has a single value and every value a single key,
+// so it can be looked up by key or by value in O(1).
+//
+// The zero value is an empty map with the RejectConflicts policy, ready to use.
+// A BiMap is not safe for concurrent use.
+//
+// Example:
+//
+//	codes := NewBiMap[string, int](RejectConflicts)
+//	codes.Put("OK", 200)
+//	codes.GetByValue(200)     // "OK", true
+//	codes.Inverse().Get(200)  // "OK", true
+//	codes.Put("Success", 200) // ErrConflict
+type BiMap[K, V comparable] struct {
+	forward  map[K]V
+	backward map[V]K
+	policy   ConflictPolicy
+	inverse  *BiMap[V, K]
+}
+
+// NewBiMap allocates and initializes an empty BiMap resolving conflicting puts with policy.
+func NewBiMap[K, V comparable](policy ConflictPolicy) *BiMap[K, V] {
+	return &BiMap[K, V]{forward: make(map[K]V), backward: make(map[V]K), policy: policy}
+}
+
+// Put binds key and value according to the conflict policy of the map.
+// It returns an error wrapping ErrConflict if the policy rejects the entry; the map is then unchanged.
+// Putting an entry that is already present is a no-op.
+func (m *BiMap[K, V]) Put(key K, value V) error {
+	m.init()
+	current, keyFound := m.forward[key]
+	owner, valueFound := m.backward[value]
+	if keyFound && valueFound && owner == key {
+		return nil
+	}
+	switch {
+	case keyFound && m.policy == RejectConflicts:
+		return fmt.Errorf("%w: key %v is bound to %v", ErrConflict, key, current)
+	case valueFound && m.policy != ForcePut:
+		return fmt.Errorf("%w: value %v is bound to %v", ErrConflict, value, owner)
+	}
+	if keyFound {
+		delete(m.backward, current)
+	}
+	if valueFound {
+		delete(m.forward, owner)
+	}
+	m.forward[key] = value
+	m.backward[value] = key
+	return nil
+}
+
+// Get retrieves the value bound to key. found is false if the key is not present.
+func (m *BiMap[K, V]) Get(key K) (value V, found bool) {
+	value, found = m.forward[key]
+	return value, found
+}
+
+// GetByValue retrieves the key bound to value. found is false if the value is not present.
+func (m *BiMap[K, V]) GetByValue(value V) (key K, found bool) {
+	key, found = m.backward[value]
+	return key, found
+}
+
+// ContainsKey reports whether key is present in the map.
+func (m *BiMap[K, V]) ContainsKey(key K) bool {
+	_, found := m.forward[key]
+	return found
+}
+
+// ContainsValue reports whether value is present in the map.
+func (m *BiMap[K, V]) ContainsValue(value V) bool {
+	_, found := m.backward[value]
+	return found
+}
+
+// Delete removes key and its value from the map and reports whether it was present.
+func (m *BiMap[K, V]) Delete(key K) bool {
+	value, found := m.forward[key]
+	if found {
+		delete(m.forward, key)
+		delete(m.backward, value)
+	}
+	return found
+}
+
+// DeleteValue removes value and its key from the map and reports whether it was present.
+func (m *BiMap[K, V]) DeleteValue(value V) bool {
+	key, found := m.backward[value]
+	if found {
+		delete(m.forward, key)
+		delete(m.backward, value)
+	}
+	return found
+}
+
+// Inverse returns a view of the map with keys and values swapped, with the same conflict policy.
+// The view shares the entries of m: changes to either are visible in both.
+// The inverse of the inverse is m.
+func (m *BiMap[K, V]) Inverse() *BiMap[V, K] {
+	m.init()
+	if m.inverse == nil {
+		m.inverse = &BiMap[V, K]{forward: m.backward, backward: m.forward, policy: m.policy, inverse: m}
+	}
+	return m.inverse
+}
+
+// init allocates the maps of a zero BiMap, so that an inverse view shares them.
+func (m *BiMap[K, V]) init() {
+	if m.forward == nil {
+		m.forward, m.backward = make(map[K]V), make(map[V]K)
+	}
+}
+
+// Keys returns the keys of the map.
+// The keys will be in an indeterminate order.
+func (m *BiMap[K, V]) Keys() []K { return Keys(m.forward) }
+
+// Values returns the values of the map.
+// The values will be in an indeterminate order.
+func (m *BiMap[K, V]) Values() []V { return Keys(m.backward) }
+
+// Map returns a copy of the entries of m as a Map.
+func (m *BiMap[K, V]) Map() Map[K, V] {
+	mapped := make(Map[K, V], len(m.forward))
+	Copy(mapped, m.forward)
+	return mapped
+}
+
+// Clone returns a copy of m with the same conflict policy. The copy does not share its entries with m.
+func (m *BiMap[K, V]) Clone() *BiMap[K, V] {
+	clone := NewBiMap[K, V](m.policy)
+	Copy(clone.forward, m.forward)
+	Copy(clone.backward, m.backward)
+	return clone
+}
+
+// Clear removes all entries from m, and from its inverse view, leaving it empty.
+func (m *BiMap[K, V]) Clear() {
+	Clear(m.forward)
+	Clear(m.backward)
+}
+
+// Executes a provided function once per each key/value pair in the map.
+func (m *BiMap[K, V]) ForEach(callbackFn func(K, V)) { ForEach(m.forward, callbackFn) }
+
+// Filter returns a new map containing only the key-value pairs for which the callback function returns true.
+// The new map has the same conflict policy.
+func (m *BiMap[K, V]) Filter(callbackFn func(K, V) bool) *BiMap[K, V] {
+	filtered := NewBiMap[K, V](m.policy)
+	filtered.forward = Filter(m.forward, callbackFn)
+	for key, value := range filtered.forward {
+		filtered.backward[value] = key
+	}
+	return filtered
+}
+
+// Some returns true if at least one key-value pair in the map causes the callback function to return true.
+func (m *BiMap[K, V]) Some(callbackFn func(K, V) bool) bool { return Some(m.forward, callbackFn) }
+
+// Every returns true if all key-value pairs in the map cause the callback function to return true.
+func (m *BiMap[K, V]) Every(callbackFn func(K, V) bool) bool { return Every(m.forward, callbackFn) }
+
+// Size returns the number of key-value pairs in the map.
+func (m *BiMap[K, V]) Size() int { return len(m.forward) }
+
+// IsEmpty returns true if the map is empty
+func (m *BiMap[K, V]) IsEmpty() bool { return m.Size() == 0 }
+
+// String formats the map like fmt formats a map: map[k1:v1 k2:v2].
+func (m *BiMap[K, V]) String() string { return fmt.Sprint(m.forward) }
@@ -0,0 +1,103 @@
+package maps_test
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	. "github.com/cramanan/go-types/maps"
+)
+
+func TestBiMapPolicies(t *testing.T) {
+	type put struct {
+		key   string
+		value int
+	}
+	testCases := []struct {
+		desc    string
+		policy  ConflictPolicy
+		put     put
+		wantErr bool
+		want    Map[string, int]
+	}{
+		{"reject existing entry", RejectConflicts, put{"a", 1}, false, Map[string, int]{"a": 1, "b": 2}},
+		{"reject new entry", RejectConflicts, put{"c", 3}, false, Map[string, int]{"a": 1, "b": 2, "c": 3}},
+		{"reject key", RejectConflicts, put{"a", 3}, true, Map[string, int]{"a": 1, "b": 2}},
+		{"reject value", RejectConflicts, put{"c", 1}, true, Map[string, int]{"a": 1, "b": 2}},
+		{"overwrite key", OverwriteKey, put{"a", 3}, false, Map[string, int]{"a": 3, "b": 2}},
+		{"overwrite value", OverwriteKey, put{"c", 1}, true, Map[string, int]{"a": 1, "b": 2}},
+		{"overwrite key and value", OverwriteKey, put{"a", 2}, true, Map[string, int]{"a": 1, "b": 2}},
+		{"force key", ForcePut, put{"a", 3}, false, Map[string, int]{"a": 3, "b": 2}},
+		{"force value", ForcePut, put{"c", 1}, false, Map[string, int]{"c": 1, "b": 2}},
+		{"force key and value", ForcePut, put{"a", 2}, false, Map[string, int]{"a": 2}},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			m := NewBiMap[string, int](tC.policy)
+			m.Put("a", 1)
+			m.Put("b", 2)
+			err := m.Put(tC.put.key, tC.put.value)
+			if gotErr := errors.Is(err, ErrConflict); gotErr != tC.wantErr {
+				t.Errorf("Put(%v) got error %v, want error %t", tC.put, err, tC.wantErr)
+			}
+			if got := m.Map(); !Equal(got, tC.want) {
+				t.Errorf("got %v, want %v", got, tC.want)
+			}
+			for key, value := range tC.want {
+				if got, _ := m.GetByValue(value); got != key {
+					t.Errorf("GetByValue(%d) got %q, want %q", value, got, key)
+				}
+			}
+			if m.Inverse().Size() != len(tC.want) {
+				t.Errorf("Inverse().Size() got %d, want %d", m.Inverse().Size(), len(tC.want))
+			}
+		})
+	}
+}
+
+func TestBiMap(t *testing.T) {
+	var m BiMap[int, string]
+	inverse := m.Inverse()
+	m.Put(1, "one")
+	m.Put(2, "two")
+	inverse.Put("three", 3)
+	inverse.DeleteValue(2)
+
+	keys := m.Keys()
+	sort.Ints(keys)
+	values := m.Values()
+	sort.Strings(values)
+	key, _ := m.GetByValue("three")
+
+	testCases := []struct {
+		desc      string
+		got, want any
+	}{
+		{"Inverse shares entries", m.Map(), Map[int, string]{1: "one", 3: "three"}},
+		{"Inverse of Inverse", inverse.Inverse() == &m, true},
+		{"Keys", keys, []int{1, 3}},
+		{"Values", values, []string{"one", "three"}},
+		{"GetByValue", key, 3},
+		{"ContainsValue", m.ContainsValue("two"), false},
+		{"Filter", m.Filter(func(k int, v string) bool { return k > 1 }).Inverse().Map(), Map[string, int]{"three": 3}},
+		{"Some", m.Some(func(k int, v string) bool { return v == "one" }), true},
+		{"Every", m.Every(func(k int, v string) bool { return k < 3 }), false},
+		{"String", m.String(), "map[1:one 3:three]"},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !reflect.DeepEqual(tC.got, tC.want) {
+				t.Errorf("got %v, want %v", tC.got, tC.want)
+			}
+		})
+	}
+
+	clone := m.Clone()
+	m.Clear()
+	if !inverse.IsEmpty() || clone.Size() != 2 {
+		t.Errorf("Clear() got inverse %v and clone %v, want empty and 2 entries", inverse, clone)
+	}
+}
@@ -0,0 +1,455 @@
+package maps
+
+import (
+	"container/heap"
+	"errors"
+	"sync"
+	"time"
+)
+
+// EvictionPolicy defines which entry a [Cache] evicts when it is full.
+type EvictionPolicy int
+
+const (
+	// LRU evicts the least recently used entry.
+	LRU EvictionPolicy = iota
+
+	// LFU evicts the least frequently used entry, and the least recently used one among ties.
+	LFU
+
+	// FIFO evicts the oldest entry, regardless of how it is used.
+	FIFO
+)
+
+// EvictionReason tells the OnEvict callback of a [Cache] why an entry left the cache.
+type EvictionReason int
+
+const (
+	// Evicted means the entry was removed by the eviction policy to respect the capacity of the cache.
+	Evicted EvictionReason = iota
+
+	// Expired means the time to live of the entry elapsed.
+	Expired
+
+	// Deleted means the entry was removed by Delete or Clear before it expired.
+	Deleted
+)
+
+// CacheOptions configures a [Cache]. The zero value is an unbounded LRU cache without expiration.
+type CacheOptions[K comparable, V any] struct {
+	// Policy chooses the entry to evict when the cache is full.
+	Policy EvictionPolicy
+
+	// MaxEntries is the maximum number of entries. Zero means no limit.
+	MaxEntries int
+
+	// MaxCost is the maximum total cost of the entries. Zero means no limit.
+	// An entry costing more than MaxCost is evicted as soon as it is set.
+	MaxCost int64
+
+	// Cost returns the cost of an entry, e.g. its size in bytes. Nil means every entry costs 1.
+	Cost func(key K, value V) int64
+
+	// TTL is the time to live of the entries set without an explicit one. Zero means they never expire.
+	TTL time.Duration
+
+	// Now returns the current time. Nil means time.Now; tests can inject a fake clock.
+	Now func() time.Time
+
+	// OnEvict, if not nil, is called after an entry leaves the cache, outside of any lock.
+	OnEvict func(key K, value V, reason EvictionReason)
+}
+
+// CacheStats holds the counters of a [Cache].
+type CacheStats struct {
+	Hits        uint64 // lookups that found a live entry
+	Misses      uint64 // lookups that found no entry or an expired one
+	Evictions   uint64 // entries removed by the eviction policy
+	Expirations uint64 // entries removed because their time to live elapsed
+	Loads       uint64 // calls of a GetOrLoad loader
+}
+
+// HitRatio returns the fraction of lookups that were hits, or 0 if there were none.
+func (s CacheStats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// errLoaderPanicked is returned to the callers waiting on a GetOrLoad whose loader panicked.
+var errLoaderPanicked = errors.New("maps: cache loader panicked")
+
+// Cache is a bounded key/value cache with a pluggable eviction policy and per-entry expiration.
+// Use [NewCache] to create one. A Cache is safe for concurrent use.
+//
+// Expired entries are removed lazily, when they are looked up or evicted, or by DeleteExpired.
+//
+// Example:
+//
+//	users := NewCache(CacheOptions[int, User]{Policy: LRU, MaxEntries: 10_000, TTL: time.Minute})
+//	user, err := users.GetOrLoad(id, fetchUser)
+type Cache[K comparable, V any] struct {
+	mu      sync.Mutex
+	options CacheOptions[K, V]
+	entries Map[K, *cacheEntry[K, V]]
+	policy  evictionPolicy[K, V]
+	cost    int64
+	stats   CacheStats
+	loading map[K]*loadCall[V]
+}
+
+// cacheEntry is a cached value with its bookkeeping.
+type cacheEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	cost    int64
+	expires time.Time // zero if the entry never expires
+
+	// Used by the LFU policy.
+	frequency uint64
+	used      uint64
+	index     int
+}
+
+// loadCall is a GetOrLoad in flight, shared by every caller asking for the same key.
+type loadCall[V any] struct {
+	done  sync.WaitGroup
+	value V
+	err   error
+}
+
+// evicted is an entry that left the cache, reported to OnEvict once the lock is released.
+type evicted[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictionReason
+}
+
+// NewCache allocates and initializes an empty Cache configured by options.
+func NewCache[K comparable, V any](options CacheOptions[K, V]) *Cache[K, V] {
+	if options.Now == nil {
+		options.Now = time.Now
+	}
+	c := &Cache[K, V]{options: options, entries: New[K, *cacheEntry[K, V]](), loading: make(map[K]*loadCall[V])}
+	c.policy = newEvictionPolicy[K, V](options.Policy)
+	return c
+}
+
+// Get returns the value cached for key. found is false if there is no live entry for key.
+func (c *Cache[K, V]) Get(key K) (value V, found bool) {
+	c.mu.Lock()
+	e, expired := c.lookup(key)
+	c.mu.Unlock()
+	c.notify(expired)
+	if e == nil {
+		return value, false
+	}
+	return e.value, true
+}
+
+// Set caches value for key with the default time to live, replacing any previous value.
+func (c *Cache[K, V]) Set(key K, value V) { c.SetWithTTL(key, value, c.options.TTL) }
+
+// SetWithTTL caches value for key for the given time to live, replacing any previous value.
+// A zero ttl means the entry never expires.
+func (c *Cache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	removed := c.set(key, value, ttl)
+	c.mu.Unlock()
+	c.notify(removed)
+}
+
+// GetOrLoad returns the value cached for key, or calls load to get it and caches it with the default time to live.
+// Concurrent calls for the same missing key share a single call of load: the others wait for its result.
+// Errors are returned to every waiting caller and are not cached.
+func (c *Cache[K, V]) GetOrLoad(key K, load func(K) (V, error)) (V, error) {
+	if load == nil {
+		panic("callback function is nil")
+	}
+	c.mu.Lock()
+	e, expired := c.lookup(key)
+	if e != nil {
+		c.mu.Unlock()
+		c.notify(expired)
+		return e.value, nil
+	}
+	if call, ok := c.loading[key]; ok {
+		c.mu.Unlock()
+		c.notify(expired)
+		call.done.Wait()
+		return call.value, call.err
+	}
+	call := &loadCall[V]{err: errLoaderPanicked}
+	call.done.Add(1)
+	c.loading[key] = call
+	c.stats.Loads++
+	c.mu.Unlock()
+	c.notify(expired)
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.loading, key)
+		if call.err == nil {
+			expired = c.set(key, call.value, c.options.TTL)
+		}
+		c.mu.Unlock()
+		call.done.Done()
+		c.notify(expired)
+	}()
+	call.value, call.err = load(key)
+	return call.value, call.err
+}
+
+// Delete removes key from the cache and reports whether it had a live entry.
+func (c *Cache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	e, found := c.entries[key]
+	var removed []evicted[K, V]
+	if found {
+		reason := Deleted
+		if c.isExpired(e, c.options.Now()) {
+			reason, found = Expired, false
+		}
+		removed = []evicted[K, V]{c.remove(e, reason)}
+	}
+	c.mu.Unlock()
+	c.notify(removed)
+	return found
+}
+
+// DeleteExpired removes every expired entry and returns how many were removed.
+func (c *Cache[K, V]) DeleteExpired() int {
+	c.mu.Lock()
+	var expired []evicted[K, V]
+	now := c.options.Now()
+	for _, e := range c.entries {
+		if c.isExpired(e, now) {
+			expired = append(expired, c.remove(e, Expired))
+		}
+	}
+	c.mu.Unlock()
+	c.notify(expired)
+	return len(expired)
+}
+
+// Clear removes every entry from the cache. The statistics are kept.
+func (c *Cache[K, V]) Clear() {
+	c.mu.Lock()
+	removed := make([]evicted[K, V], 0, len(c.entries))
+	now := c.options.Now()
+	for _, e := range c.entries {
+		reason := Deleted
+		if c.isExpired(e, now) {
+			reason = Expired
+		}
+		removed = append(removed, c.remove(e, reason))
+	}
+	c.mu.Unlock()
+	c.notify(removed)
+}
+
+// Len returns the number of entries in the cache, including expired entries not removed yet.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Cost returns the total cost of the entries in the cache.
+func (c *Cache[K, V]) Cost() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cost
+}
+
+// Stats returns a snapshot of the counters of the cache.
+func (c *Cache[K, V]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// lookup returns the live entry of key and records the access, or removes the entry if it expired.
+// The lock must be held.
+func (c *Cache[K, V]) lookup(key K) (*cacheEntry[K, V], []evicted[K, V]) {
+	e, found := c.entries[key]
+	if !found {
+		c.stats.Misses++
+		return nil, nil
+	}
+	if c.isExpired(e, c.options.Now()) {
+		c.stats.Misses++
+		return nil, []evicted[K, V]{c.remove(e, Expired)}
+	}
+	c.stats.Hits++
+	c.policy.access(e)
+	return e, nil
+}
+
+// set stores an entry and evicts entries so that the cache fits its capacity. The lock must be held.
+func (c *Cache[K, V]) set(key K, value V, ttl time.Duration) (removed []evicted[K, V]) {
+	var expires time.Time
+	if ttl > 0 {
+		expires = c.options.Now().Add(ttl)
+	}
+	cost := int64(1)
+	if c.options.Cost != nil {
+		cost = c.options.Cost(key, value)
+	}
+
+	e, found := c.entries[key]
+	switch {
+	case c.options.MaxCost > 0 && cost > c.options.MaxCost:
+		// The entry can never fit: it replaces any previous value and is evicted right away.
+		if found {
+			removed = append(removed, c.remove(e, Deleted))
+		}
+		c.stats.Evictions++
+		return append(removed, evicted[K, V]{key, value, Evicted})
+	case found:
+		c.cost += cost - e.cost
+		e.value, e.cost, e.expires = value, cost, expires
+		c.policy.access(e)
+	default:
+		// Make room first, so that a new entry is not chosen as its own victim.
+		for len(c.entries) > 0 && c.overCapacity(1, cost) {
+			removed = append(removed, c.remove(c.policy.victim(), Evicted))
+		}
+		e = &cacheEntry[K, V]{key: key, value: value, cost: cost, expires: expires}
+		c.entries[key] = e
+		c.cost += cost
+		c.policy.push(e)
+	}
+
+	// A replaced value may cost more than the previous one.
+	for c.overCapacity(0, 0) {
+		removed = append(removed, c.remove(c.policy.victim(), Evicted))
+	}
+	return removed
+}
+
+// overCapacity reports whether the cache would exceed its capacity with extra entries and cost.
+// The lock must be held.
+func (c *Cache[K, V]) overCapacity(entries int, cost int64) bool {
+	return (c.options.MaxEntries > 0 && len(c.entries)+entries > c.options.MaxEntries) ||
+		(c.options.MaxCost > 0 && c.cost+cost > c.options.MaxCost)
+}
+
+// remove deletes an entry and counts it. The lock must be held.
+func (c *Cache[K, V]) remove(e *cacheEntry[K, V], reason EvictionReason) evicted[K, V] {
+	delete(c.entries, e.key)
+	c.policy.remove(e)
+	c.cost -= e.cost
+	switch reason {
+	case Evicted:
+		c.stats.Evictions++
+	case Expired:
+		c.stats.Expirations++
+	}
+	return evicted[K, V]{e.key, e.value, reason}
+}
+
+func (c *Cache[K, V]) isExpired(e *cacheEntry[K, V], now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
+
+// notify calls OnEvict for every removed entry. The lock must not be held.
+func (c *Cache[K, V]) notify(removed []evicted[K, V]) {
+	if c.options.OnEvict == nil {
+		return
+	}
+	for _, r := range removed {
+		c.options.OnEvict(r.key, r.value, r.reason)
+	}
+}
+
+// evictionPolicy orders the entries of a cache to choose which one to evict.
+type evictionPolicy[K comparable, V any] interface {
+	push(e *cacheEntry[K, V])
+	access(e *cacheEntry[K, V])
+	remove(e *cacheEntry[K, V])
+	victim() *cacheEntry[K, V]
+}
+
+func newEvictionPolicy[K comparable, V any](policy EvictionPolicy) evictionPolicy[K, V] {
+	switch policy {
+	case LFU:
+		return &lfuPolicy[K, V]{}
+	case FIFO:
+		return &queuePolicy[K, V]{order: NewOrderedMap[K, *cacheEntry[K, V]]()}
+	}
+	return &queuePolicy[K, V]{order: NewOrderedMap[K, *cacheEntry[K, V]](), moveOnAccess: true}
+}
+
+// queuePolicy evicts from the front of an OrderedMap.
+// LRU moves an entry to the back when it is accessed; FIFO does not.
+type queuePolicy[K comparable, V any] struct {
+	order        *OrderedMap[K, *cacheEntry[K, V]]
+	moveOnAccess bool
+}
+
+func (p *queuePolicy[K, V]) push(e *cacheEntry[K, V]) { p.order.Set(e.key, e) }
+
+func (p *queuePolicy[K, V]) access(e *cacheEntry[K, V]) {
+	if p.moveOnAccess {
+		p.order.MoveToBack(e.key)
+	}
+}
+
+func (p *queuePolicy[K, V]) remove(e *cacheEntry[K, V]) { p.order.Delete(e.key) }
+
+func (p *queuePolicy[K, V]) victim() *cacheEntry[K, V] {
+	_, e, _ := p.order.Front()
+	return e
+}
+
+// lfuPolicy is a min-heap of entries by frequency, then by last use.
+type lfuPolicy[K comparable, V any] struct {
+	entries []*cacheEntry[K, V]
+	clock   uint64
+}
+
+func (p *lfuPolicy[K, V]) push(e *cacheEntry[K, V]) {
+	p.clock++
+	e.frequency, e.used = 1, p.clock
+	heap.Push(p, e)
+}
+
+func (p *lfuPolicy[K, V]) access(e *cacheEntry[K, V]) {
+	p.clock++
+	e.frequency++
+	e.used = p.clock
+	heap.Fix(p, e.index)
+}
+
+func (p *lfuPolicy[K, V]) remove(e *cacheEntry[K, V]) { heap.Remove(p, e.index) }
+
+func (p *lfuPolicy[K, V]) victim() *cacheEntry[K, V] { return p.entries[0] }
+
+func (p *lfuPolicy[K, V]) Len() int { return len(p.entries) }
+
+func (p *lfuPolicy[K, V]) Less(i, j int) bool {
+	a, b := p.entries[i], p.entries[j]
+	if a.frequency != b.frequency {
+		return a.frequency < b.frequency
+	}
+	return a.used < b.used
+}
+
+func (p *lfuPolicy[K, V]) Swap(i, j int) {
+	p.entries[i], p.entries[j] = p.entries[j], p.entries[i]
+	p.entries[i].index, p.entries[j].index = i, j
+}
+
+func (p *lfuPolicy[K, V]) Push(x any) {
+	e := x.(*cacheEntry[K, V])
+	e.index = len(p.entries)
+	p.entries = append(p.entries, e)
+}
+
+func (p *lfuPolicy[K, V]) Pop() any {
+	last := p.entries[len(p.entries)-1]
+	p.entries = p.entries[:len(p.entries)-1]
+	return last
+}
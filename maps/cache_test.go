@@ -0,0 +1,240 @@
+package maps_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/cramanan/go-types/maps"
+)
+
+// fakeClock is a clock that only moves when told to.
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time          { return c.now }
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestCacheEviction(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		policy EvictionPolicy
+		want   []string
+	}{
+		// a, b and c are set, a is read twice and b once, then d is set.
+		{"LRU", LRU, []string{"a", "b", "d"}},
+		{"LFU", LFU, []string{"a", "b", "d"}},
+		{"FIFO", FIFO, []string{"b", "c", "d"}},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var evictions []string
+			c := NewCache(CacheOptions[string, int]{
+				Policy:     tC.policy,
+				MaxEntries: 3,
+				OnEvict:    func(k string, _ int, _ EvictionReason) { evictions = append(evictions, k) },
+			})
+			c.Set("a", 1)
+			c.Set("b", 2)
+			c.Set("c", 3)
+			c.Get("a")
+			c.Get("b")
+			c.Get("a")
+			c.Set("d", 4)
+
+			var got []string
+			for _, k := range []string{"a", "b", "c", "d"} {
+				if _, found := c.Get(k); found {
+					got = append(got, k)
+				}
+			}
+			if !reflect.DeepEqual(got, tC.want) {
+				t.Errorf("got %v, want %v", got, tC.want)
+			}
+			if len(evictions) != 1 || c.Stats().Evictions != 1 {
+				t.Errorf("got evictions %v and %d in stats, want 1", evictions, c.Stats().Evictions)
+			}
+		})
+	}
+}
+
+func TestCacheLFUTies(t *testing.T) {
+	c := NewCache(CacheOptions[int, int]{Policy: LFU, MaxEntries: 2})
+	c.Set(1, 1)
+	c.Set(2, 2)
+	c.Get(1)
+	c.Get(2)
+	c.Set(3, 3) // 1 and 2 were used twice: 1 is the least recently used.
+	c.Set(4, 4) // 3 was used once.
+
+	_, found1 := c.Get(1)
+	_, found2 := c.Get(2)
+	_, found3 := c.Get(3)
+	if got, want := []bool{found1, found2, found3}, []bool{false, true, false}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestCacheCost(t *testing.T) {
+	var evicted []string
+	c := NewCache(CacheOptions[string, string]{
+		MaxCost: 10,
+		Cost:    func(_ string, v string) int64 { return int64(len(v)) },
+		OnEvict: func(k string, _ string, _ EvictionReason) { evicted = append(evicted, k) },
+	})
+	c.Set("a", "1234")
+	c.Set("b", "1234")
+	c.Set("c", "1234")
+	c.Set("huge", "12345678901")
+	c.Set("b", "12")
+
+	if got, want := evicted, []string{"a", "huge"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("evicted %v, want %v", got, want)
+	}
+	if got := c.Cost(); got != 6 {
+		t.Errorf("Cost() got %d, want 6", got)
+	}
+	var reasons []string
+	c = NewCache(CacheOptions[string, string]{
+		MaxCost: 10,
+		Cost:    func(_ string, v string) int64 { return int64(len(v)) },
+		OnEvict: func(k string, v string, reason EvictionReason) {
+			reasons = append(reasons, fmt.Sprint(k, "=", v, " ", reason == Evicted))
+		},
+	})
+	c.Set("a", "1234")
+	c.Set("a", "12345678901")
+	if got, want := reasons, []string{"a=1234 false", "a=12345678901 true"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("replacing with a value that can never fit reported %v, want %v", got, want)
+	}
+	if _, ok := c.Get("a"); ok || c.Cost() != 0 {
+		t.Errorf("replacing with a value that can never fit left the entry, cost %d", c.Cost())
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	reasons := map[string]EvictionReason{}
+	c := NewCache(CacheOptions[string, int]{
+		TTL:     time.Minute,
+		Now:     clock.Now,
+		OnEvict: func(k string, _ int, reason EvictionReason) { reasons[k] = reason },
+	})
+	c.Set("default", 1)
+	c.SetWithTTL("short", 2, time.Second)
+	c.SetWithTTL("forever", 3, 0)
+	c.Set("stale", 4)
+	c.Set("deleted", 5)
+	c.Delete("deleted")
+	c.Set("deleted late", 6)
+
+	clock.Advance(time.Second)
+	_, shortFound := c.Get("short")
+	_, defaultFound := c.Get("default")
+
+	clock.Advance(time.Minute)
+	_, defaultFoundLater := c.Get("default")
+	_, foreverFound := c.Get("forever")
+	deletedLate := c.Delete("deleted late")
+	purged := c.DeleteExpired()
+
+	testCases := []struct {
+		desc      string
+		got, want any
+	}{
+		{"short expired", shortFound, false},
+		{"default alive", defaultFound, true},
+		{"default expired", defaultFoundLater, false},
+		{"no TTL", foreverFound, true},
+		{"Delete expired", deletedLate, false},
+		{"DeleteExpired", purged, 1},
+		{"reasons", reasons, map[string]EvictionReason{
+			"short": Expired, "default": Expired, "stale": Expired, "deleted": Deleted, "deleted late": Expired,
+		}},
+		{"stats", c.Stats(), CacheStats{Hits: 2, Misses: 2, Expirations: 4}},
+		{"HitRatio", c.Stats().HitRatio(), 0.5},
+		{"Len", c.Len(), 1},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !reflect.DeepEqual(tC.got, tC.want) {
+				t.Errorf("got %v, want %v", tC.got, tC.want)
+			}
+		})
+	}
+}
+
+func TestCacheGetOrLoad(t *testing.T) {
+	c := NewCache(CacheOptions[int, string]{})
+	var calls int32
+	release := make(chan struct{})
+	load := func(k int) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return fmt.Sprint(k), nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]string, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = c.GetOrLoad(7, load)
+		}(i)
+	}
+	// Give the goroutines time to pile up on the same load.
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("load called %d times, want 1", calls)
+	}
+	for _, result := range results {
+		if result != "7" {
+			t.Errorf("GetOrLoad got %q, want %q", result, "7")
+		}
+	}
+	if got, _ := c.Get(7); got != "7" {
+		t.Errorf("Get after GetOrLoad got %q, want %q", got, "7")
+	}
+
+	errLoad := errors.New("load failed")
+	if _, err := c.GetOrLoad(8, func(int) (string, error) { return "", errLoad }); err != errLoad {
+		t.Errorf("GetOrLoad got error %v, want %v", err, errLoad)
+	}
+	if _, found := c.Get(8); found {
+		t.Error("GetOrLoad cached a failed load")
+	}
+
+	func() {
+		defer func() { recover() }()
+		c.GetOrLoad(9, func(int) (string, error) { panic("boom") })
+	}()
+	if _, err := c.GetOrLoad(9, func(int) (string, error) { return "9", nil }); err != nil {
+		t.Errorf("GetOrLoad after a panicking load got error %v", err)
+	}
+	if got := c.Stats().Loads; got != 4 {
+		t.Errorf("Stats().Loads got %d, want 4", got)
+	}
+}
+
+func TestCacheClear(t *testing.T) {
+	var cleared []int
+	c := NewCache(CacheOptions[int, int]{OnEvict: func(k, _ int, _ EvictionReason) { cleared = append(cleared, k) }})
+	for i := 0; i < 3; i++ {
+		c.Set(i, i)
+	}
+	c.Clear()
+	sort.Ints(cleared)
+	if !reflect.DeepEqual(cleared, []int{0, 1, 2}) || c.Len() != 0 {
+		t.Errorf("Clear() reported %v and left %d entries", cleared, c.Len())
+	}
+}
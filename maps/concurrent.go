@@ -0,0 +1,349 @@
+package maps
+
+import (
+	"hash/maphash"
+	"math"
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+// SyncMap is a map safe for concurrent use by multiple goroutines, guarded by a single read/write lock.
+// Loads run in parallel; writes are serialized. For write-heavy workloads, see [ShardedMap].
+//
+// The zero value is an empty map ready to use. A SyncMap must not be copied after first use.
+type SyncMap[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+}
+
+// NewSyncMap allocates and initializes an empty SyncMap.
+func NewSyncMap[K comparable, V any]() *SyncMap[K, V] { return &SyncMap[K, V]{m: make(map[K]V)} }
+
+// Load returns the value stored for key. found is false if the key is not present.
+func (m *SyncMap[K, V]) Load(key K) (value V, found bool) {
+	m.mu.RLock()
+	value, found = m.m[key]
+	m.mu.RUnlock()
+	return value, found
+}
+
+// Store sets the value for key.
+func (m *SyncMap[K, V]) Store(key K, value V) {
+	m.mu.Lock()
+	m.store(key, value)
+	m.mu.Unlock()
+}
+
+// LoadOrStore returns the existing value for key if present, with loaded true.
+// Otherwise, it stores and returns value, with loaded false.
+func (m *SyncMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if actual, loaded = m.m[key]; loaded {
+		return actual, true
+	}
+	m.store(key, value)
+	return value, false
+}
+
+// LoadAndDelete deletes key and returns its previous value. loaded is false if the key was not present.
+func (m *SyncMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if value, loaded = m.m[key]; loaded {
+		delete(m.m, key)
+	}
+	return value, loaded
+}
+
+// Delete deletes key from the map.
+func (m *SyncMap[K, V]) Delete(key K) {
+	m.mu.Lock()
+	delete(m.m, key)
+	m.mu.Unlock()
+}
+
+// CompareAndSwap stores new for key if its current value is equal to old, and reports whether it did.
+// Values are compared with ==, which panics if V is not comparable, like [sync.Map.CompareAndSwap].
+func (m *SyncMap[K, V]) CompareAndSwap(key K, old, new V) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if current, found := m.m[key]; !found || any(current) != any(old) {
+		return false
+	}
+	m.m[key] = new
+	return true
+}
+
+// Compute atomically updates the value of key.
+// The remapping function is called with the current value, or the zero value and false if the key is not present.
+// If it returns true, its value is stored; otherwise the key is deleted.
+// Compute returns the value and presence of the key after the update.
+//
+// The map is locked while remapping runs: it must not use the map.
+//
+// Example:
+//
+//	hits.Compute(path, func(count int, _ bool) (int, bool) { return count + 1, true })
+func (m *SyncMap[K, V]) Compute(key K, remapping func(value V, loaded bool) (V, bool)) (value V, ok bool) {
+	if remapping == nil {
+		panic("callback function is nil")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	current, loaded := m.m[key]
+	if value, ok = remapping(current, loaded); ok {
+		m.store(key, value)
+	} else {
+		delete(m.m, key)
+	}
+	return value, ok
+}
+
+// Range calls the callback function for each key/value pair of the map until it returns false.
+// The pairs are those of a snapshot taken when Range starts, so the callback function may use the map.
+func (m *SyncMap[K, V]) Range(callbackFn func(K, V) bool) {
+	if callbackFn == nil {
+		panic("callback function is nil")
+	}
+	for key, value := range m.ToMap() {
+		if !callbackFn(key, value) {
+			return
+		}
+	}
+}
+
+// ToMap returns a snapshot of the map as a plain Map.
+func (m *SyncMap[K, V]) ToMap() Map[K, V] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	mapped := make(Map[K, V], len(m.m))
+	Copy(mapped, m.m)
+	return mapped
+}
+
+// Size returns the number of key-value pairs in the map.
+func (m *SyncMap[K, V]) Size() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.m)
+}
+
+// Clear removes all entries from m, leaving it empty.
+func (m *SyncMap[K, V]) Clear() {
+	m.mu.Lock()
+	m.m = nil
+	m.mu.Unlock()
+}
+
+// store sets the value for key, allocating the map if needed. The lock must be held.
+func (m *SyncMap[K, V]) store(key K, value V) {
+	if m.m == nil {
+		m.m = make(map[K]V)
+	}
+	m.m[key] = value
+}
+
+// DefaultShards is the number of shards of a ShardedMap when none is given.
+const DefaultShards = 32
+
+// ShardedMap is a map safe for concurrent use, split into shards that each have their own lock.
+// Keys are spread over the shards by hash, so writes to different shards do not contend.
+// Operations on a single key are atomic; Range, ToMap and Size visit the shards one at a time
+// and do not see a consistent snapshot of concurrent writes.
+type ShardedMap[K comparable, V any] struct {
+	shards []shard[K, V]
+	hash   func(K) uint64
+}
+
+// shard is a SyncMap padded to its own cache line, so that locking a shard does not slow down its neighbours.
+type shard[K comparable, V any] struct {
+	SyncMap[K, V]
+	_ [64 - unsafe.Sizeof(SyncMap[int, int]{})%64]byte
+}
+
+// NewShardedMap allocates and initializes an empty ShardedMap with the given number of shards,
+// rounded up to a power of two. Zero or less means DefaultShards.
+//
+// Keys are hashed by value like the built-in map does: pointers and channels by address,
+// structs and arrays field by field and interfaces by their dynamic value.
+// Keys other than strings and integers are hashed through reflection, which is slower: see [NewShardedMapFunc].
+func NewShardedMap[K comparable, V any](shards int) *ShardedMap[K, V] {
+	seed := maphash.MakeSeed()
+	return NewShardedMapFunc[K, V](shards, func(key K) uint64 { return hashKey(seed, key) })
+}
+
+// NewShardedMapFunc is like [NewShardedMap] but spreads keys over the shards with hash.
+// Equal keys must have equal hashes.
+func NewShardedMapFunc[K comparable, V any](shards int, hash func(K) uint64) *ShardedMap[K, V] {
+	if hash == nil {
+		panic("callback function is nil")
+	}
+	if shards <= 0 {
+		shards = DefaultShards
+	}
+	n := 1
+	for n < shards {
+		n <<= 1
+	}
+	return &ShardedMap[K, V]{shards: make([]shard[K, V], n), hash: hash}
+}
+
+// shard returns the shard holding key.
+func (m *ShardedMap[K, V]) shard(key K) *SyncMap[K, V] {
+	return &m.shards[m.hash(key)&uint64(len(m.shards)-1)].SyncMap
+}
+
+// Load returns the value stored for key. found is false if the key is not present.
+func (m *ShardedMap[K, V]) Load(key K) (value V, found bool) { return m.shard(key).Load(key) }
+
+// Store sets the value for key.
+func (m *ShardedMap[K, V]) Store(key K, value V) { m.shard(key).Store(key, value) }
+
+// LoadOrStore returns the existing value for key if present, with loaded true.
+// Otherwise, it stores and returns value, with loaded false.
+func (m *ShardedMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	return m.shard(key).LoadOrStore(key, value)
+}
+
+// LoadAndDelete deletes key and returns its previous value. loaded is false if the key was not present.
+func (m *ShardedMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	return m.shard(key).LoadAndDelete(key)
+}
+
+// Delete deletes key from the map.
+func (m *ShardedMap[K, V]) Delete(key K) { m.shard(key).Delete(key) }
+
+// CompareAndSwap stores new for key if its current value is equal to old, and reports whether it did.
+// See [SyncMap.CompareAndSwap].
+func (m *ShardedMap[K, V]) CompareAndSwap(key K, old, new V) bool {
+	return m.shard(key).CompareAndSwap(key, old, new)
+}
+
+// Compute atomically updates the value of key. See [SyncMap.Compute].
+// Only the shard of key is locked while remapping runs.
+func (m *ShardedMap[K, V]) Compute(key K, remapping func(value V, loaded bool) (V, bool)) (value V, ok bool) {
+	return m.shard(key).Compute(key, remapping)
+}
+
+// Range calls the callback function for each key/value pair of the map until it returns false.
+// Each shard is snapshotted just before it is visited, so the callback function may use the map.
+func (m *ShardedMap[K, V]) Range(callbackFn func(K, V) bool) {
+	if callbackFn == nil {
+		panic("callback function is nil")
+	}
+	for i := range m.shards {
+		for key, value := range m.shards[i].ToMap() {
+			if !callbackFn(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// ToMap returns the content of the map as a plain Map.
+func (m *ShardedMap[K, V]) ToMap() Map[K, V] {
+	mapped := New[K, V]()
+	for i := range m.shards {
+		shard := &m.shards[i]
+		shard.mu.RLock()
+		Copy(mapped, shard.m)
+		shard.mu.RUnlock()
+	}
+	return mapped
+}
+
+// Size returns the number of key-value pairs in the map.
+func (m *ShardedMap[K, V]) Size() (size int) {
+	for i := range m.shards {
+		size += m.shards[i].Size()
+	}
+	return size
+}
+
+// Clear removes all entries from m, leaving it empty.
+func (m *ShardedMap[K, V]) Clear() {
+	for i := range m.shards {
+		m.shards[i].Clear()
+	}
+}
+
+// hashKey hashes keys of common types directly and any other key through reflection.
+func hashKey[K comparable](seed maphash.Seed, key K) uint64 {
+	switch k := any(key).(type) {
+	case string:
+		return maphash.String(seed, k)
+	case int:
+		return mix(uint64(k))
+	case int64:
+		return mix(uint64(k))
+	case uint64:
+		return mix(k)
+	}
+	return hashValue(seed, reflect.ValueOf(&key).Elem())
+}
+
+// hashValue hashes a comparable value so that equal values, as defined by ==, have equal hashes.
+func hashValue(seed maphash.Seed, v reflect.Value) uint64 {
+	switch v.Kind() {
+	case reflect.String:
+		return maphash.String(seed, v.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return mix(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return mix(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return hashFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		return mix(hashFloat(real(c)) ^ hashFloat(imag(c))<<1)
+	case reflect.Bool:
+		if v.Bool() {
+			return mix(1)
+		}
+		return mix(0)
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return mix(uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			return mix(0)
+		}
+		return hashValue(seed, v.Elem())
+	case reflect.Struct:
+		var h uint64
+		for i := 0; i < v.NumField(); i++ {
+			// == ignores blank fields.
+			if v.Type().Field(i).Name != "_" {
+				h = mix(h ^ hashValue(seed, v.Field(i)))
+			}
+		}
+		return h
+	case reflect.Array:
+		var h uint64
+		for i := 0; i < v.Len(); i++ {
+			h = mix(h ^ hashValue(seed, v.Index(i)))
+		}
+		return h
+	}
+	// Maps, slices and funcs cannot be keys; an interface holding one panics when compared anyway.
+	panic("maps: unhashable key of type " + v.Type().String())
+}
+
+// hashFloat hashes a float so that +0 and -0, which are equal keys, have the same hash.
+func hashFloat(f float64) uint64 {
+	if f == 0 {
+		f = 0
+	}
+	return mix(math.Float64bits(f))
+}
+
+// mix scrambles the bits of x (splitmix64 finalizer) so that consecutive integers land in different shards.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
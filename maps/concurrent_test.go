@@ -0,0 +1,219 @@
+package maps_test
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"unsafe"
+
+	. "github.com/cramanan/go-types/maps"
+)
+
+// concurrentMap is the method set shared by SyncMap and ShardedMap.
+type concurrentMap[K comparable, V any] interface {
+	Load(K) (V, bool)
+	Store(K, V)
+	LoadOrStore(K, V) (V, bool)
+	LoadAndDelete(K) (V, bool)
+	Delete(K)
+	CompareAndSwap(key K, old, new V) bool
+	Compute(K, func(V, bool) (V, bool)) (V, bool)
+	Range(func(K, V) bool)
+	ToMap() Map[K, V]
+	Size() int
+	Clear()
+}
+
+func TestConcurrentMaps(t *testing.T) {
+	implementations := map[string]func() concurrentMap[string, int]{
+		"SyncMap":    func() concurrentMap[string, int] { return &SyncMap[string, int]{} },
+		"ShardedMap": func() concurrentMap[string, int] { return NewShardedMap[string, int](4) },
+		"ShardedMapFunc": func() concurrentMap[string, int] {
+			return NewShardedMapFunc[string, int](3, func(s string) uint64 { return uint64(len(s)) })
+		},
+	}
+
+	for name, newMap := range implementations {
+		t.Run(name, func(t *testing.T) {
+			m := newMap()
+			m.Store("a", 1)
+			actual, loaded := m.LoadOrStore("a", 2)
+			stored, storedLoaded := m.LoadOrStore("b", 3)
+			swapped := m.CompareAndSwap("a", 1, 10)
+			notSwapped := m.CompareAndSwap("b", 1, 10)
+			computed, kept := m.Compute("c", func(v int, loaded bool) (int, bool) { return v + 5, !loaded })
+			_, removed := m.Compute("b", func(v int, loaded bool) (int, bool) { return v, false })
+			deleted, deletedLoaded := m.LoadAndDelete("c")
+			m.Store("d", 4)
+			m.Delete("d")
+
+			var ranged int
+			m.Range(func(k string, v int) bool {
+				if _, found := m.Load(k); found { // Range does not hold the locks, so the map can be used.
+					ranged++
+				}
+				return true
+			})
+
+			testCases := []struct {
+				desc      string
+				got, want any
+			}{
+				{"LoadOrStore loaded", []any{actual, loaded}, []any{1, true}},
+				{"LoadOrStore stored", []any{stored, storedLoaded}, []any{3, false}},
+				{"CompareAndSwap", []bool{swapped, notSwapped}, []bool{true, false}},
+				{"Compute", []any{computed, kept, removed}, []any{5, true, false}},
+				{"LoadAndDelete", []any{deleted, deletedLoaded}, []any{5, true}},
+				{"Range", ranged, 1},
+				{"ToMap", m.ToMap(), Map[string, int]{"a": 10}},
+				{"Size", m.Size(), 1},
+			}
+			for _, tC := range testCases {
+				t.Run(tC.desc, func(t *testing.T) {
+					if !reflect.DeepEqual(tC.got, tC.want) {
+						t.Errorf("got %v, want %v", tC.got, tC.want)
+					}
+				})
+			}
+
+			m.Clear()
+			if _, found := m.Load("a"); found || m.Size() != 0 {
+				t.Errorf("Clear() left %v", m.ToMap())
+			}
+		})
+	}
+}
+
+func TestConcurrentMapsCompute(t *testing.T) {
+	syncMap := &SyncMap[int, int]{}
+	sharded := NewShardedMap[int, int](0)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				increment := func(v int, _ bool) (int, bool) { return v + 1, true }
+				syncMap.Compute(i%10, increment)
+				sharded.Compute(i%10, increment)
+			}
+		}()
+	}
+	wg.Wait()
+
+	want := Map[int, int]{}
+	for i := 0; i < 10; i++ {
+		want[i] = 800
+	}
+	if got := syncMap.ToMap(); !Equal(got, want) {
+		t.Errorf("SyncMap got %v, want %v", got, want)
+	}
+	if got := sharded.ToMap(); !Equal(got, want) {
+		t.Errorf("ShardedMap got %v, want %v", got, want)
+	}
+}
+
+func TestShardedMapKeys(t *testing.T) {
+	type node struct{ n int }
+	pointers := NewShardedMap[*node, int](64)
+	k := &node{1}
+	pointers.Store(k, 1)
+	k.n = 2
+	pointers.Store(k, 2)
+	if v, ok := pointers.Load(k); !ok || v != 2 || pointers.Size() != 1 {
+		t.Errorf("ShardedMap with a mutated pointer key got %v, %v, size %d", v, ok, pointers.Size())
+	}
+
+	type point struct{ x, y float64 }
+	structs := NewShardedMap[point, int](64)
+	negativeZero := math.Copysign(0, -1)
+	structs.Store(point{0, 1}, 1)
+	structs.Store(point{negativeZero, 1}, 2)
+	if v, ok := structs.Load(point{0, 1}); !ok || v != 2 || structs.Size() != 1 {
+		t.Errorf("ShardedMap with ±0 struct keys got %v, %v, size %d", v, ok, structs.Size())
+	}
+
+	type padded struct {
+		n int
+		_ [8]byte
+	}
+	blanks := NewShardedMap[padded, int](64)
+	for i := 0; i < 100; i++ {
+		var key padded
+		key.n = i
+		// Blank fields cannot be assigned: write garbage into one through unsafe, which == ignores.
+		*(*[8]byte)(unsafe.Add(unsafe.Pointer(&key), unsafe.Sizeof(key.n))) = [8]byte{byte(i), 1}
+		blanks.Store(key, i)
+		if v, ok := blanks.Load(padded{n: i}); !ok || v != i {
+			t.Fatalf("ShardedMap with a blank field got %v, %v for %d", v, ok, i)
+		}
+	}
+
+}
+
+// mutexMap is the mutex-wrapped Map the concurrent maps are benchmarked against.
+type mutexMap struct {
+	mu sync.Mutex
+	m  Map[string, int]
+}
+
+func (m *mutexMap) Load(key string) (int, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.m.Get(key)
+}
+
+func (m *mutexMap) Store(key string, value int) {
+	m.mu.Lock()
+	m.m.Set(key, value)
+	m.mu.Unlock()
+}
+
+func BenchmarkConcurrentMaps(b *testing.B) {
+	keys := make([]string, 1024)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+	type loadStorer interface {
+		Load(string) (int, bool)
+		Store(string, int)
+	}
+	implementations := []struct {
+		name   string
+		newMap func() loadStorer
+	}{
+		{"Mutex", func() loadStorer { return &mutexMap{m: New[string, int]()} }},
+		{"SyncMap", func() loadStorer { return &SyncMap[string, int]{} }},
+		{"ShardedMap", func() loadStorer { return NewShardedMap[string, int](0) }},
+	}
+	workloads := []struct {
+		name        string
+		writeEveryN int
+	}{
+		{"ReadMostly", 10},
+		{"WriteHeavy", 2},
+	}
+
+	for _, w := range workloads {
+		for _, impl := range implementations {
+			b.Run(w.name+"/"+impl.name, func(b *testing.B) {
+				m := impl.newMap()
+				for i, key := range keys {
+					m.Store(key, i)
+				}
+				b.RunParallel(func(pb *testing.PB) {
+					for i := 0; pb.Next(); i++ {
+						key := keys[i%len(keys)]
+						if i%w.writeEveryN == 0 {
+							m.Store(key, i)
+						} else {
+							m.Load(key)
+						}
+					}
+				})
+			})
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package maps
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrPatch is returned by [Patch] when a change does not apply to the map.
+var ErrPatch = errors.New("maps: patch does not apply")
+
+// ChangeKind is the kind of a [Change].
+type ChangeKind int
+
+const (
+	// Added means the path exists only in the new map.
+	Added ChangeKind = iota
+
+	// Removed means the path exists only in the old map.
+	Removed
+
+	// Changed means the path exists in both maps with different values.
+	Changed
+)
+
+// Change is a difference between two maps at a path.
+type Change struct {
+	// Path leads to the changed value. Map keys are formatted with fmt.Sprint and slice indices in decimal.
+	Path Path
+
+	Kind ChangeKind
+
+	// Old is the value in the old map, nil if Kind is Added.
+	Old any
+
+	// New is the value in the new map, nil if Kind is Removed.
+	New any
+
+	keys []any // the raw map keys and slice indices of Path, to apply the change
+}
+
+// Changes is a list of changes, as returned by [Diff].
+type Changes []Change
+
+// Diff returns the changes that turn the map a into the map b.
+//
+// Nested values that are maps or slices (other than []byte) on both sides are compared recursively:
+// map keys are matched by equality and slice elements by index.
+// Other values are compared using eq, or reflect.DeepEqual if eq is nil.
+// The changes are sorted by path, map keys being ordered by their fmt.Sprint representation.
+//
+// Example:
+//
+//	if changes := Diff(got, want, nil); len(changes) > 0 {
+//		t.Errorf("unexpected config:\n%s", changes)
+//	}
+func Diff[M ~map[K]V, K comparable, V any](a, b M, eq func(x, y any) bool) Changes {
+	if eq == nil {
+		eq = reflect.DeepEqual
+	}
+	// A nil map is an empty map, not a different value.
+	if a == nil {
+		a = M{}
+	}
+	if b == nil {
+		b = M{}
+	}
+	var changes Changes
+	diffValues(reflect.ValueOf(a), reflect.ValueOf(b), nil, nil, eq, &changes)
+	return changes
+}
+
+// diffValues appends the changes between the values a and b at path.
+func diffValues(a, b reflect.Value, path Path, keys []any, eq func(x, y any) bool, changes *Changes) {
+	a, b = unwrap(a), unwrap(b)
+	switch {
+	case isContainer(a, reflect.Map) && isContainer(b, reflect.Map):
+		// Keys are matched by equality; their string form only orders them, as distinct keys can print the same.
+		mapKeys := a.MapKeys()
+		for _, key := range b.MapKeys() {
+			if !mapIndex(a, key).IsValid() {
+				mapKeys = append(mapKeys, key)
+			}
+		}
+		names := make([]string, len(mapKeys))
+		for i, key := range mapKeys {
+			names[i] = fmt.Sprint(key.Interface())
+		}
+		sort.Stable(keysByName{mapKeys, names})
+		for i, key := range mapKeys {
+			name := names[i]
+			x, y := mapIndex(a, key), mapIndex(b, key)
+			keyPath, keyKeys := path.append(name), appendKey(keys, key.Interface())
+			switch {
+			case !x.IsValid():
+				*changes = append(*changes, Change{keyPath, Added, nil, y.Interface(), keyKeys})
+			case !y.IsValid():
+				*changes = append(*changes, Change{keyPath, Removed, x.Interface(), nil, keyKeys})
+			default:
+				diffValues(x, y, keyPath, keyKeys, eq, changes)
+			}
+		}
+
+	case isContainer(a, reflect.Slice) && isContainer(b, reflect.Slice):
+		for i := 0; i < a.Len() && i < b.Len(); i++ {
+			diffValues(a.Index(i), b.Index(i), path.append(strconv.Itoa(i)), appendKey(keys, i), eq, changes)
+		}
+		for i := a.Len(); i < b.Len(); i++ {
+			*changes = append(*changes, Change{path.append(strconv.Itoa(i)), Added, nil, b.Index(i).Interface(), appendKey(keys, i)})
+		}
+		// Removed elements are listed from the end, so that they can be applied in order.
+		for i := a.Len() - 1; i >= b.Len(); i-- {
+			*changes = append(*changes, Change{path.append(strconv.Itoa(i)), Removed, a.Index(i).Interface(), nil, appendKey(keys, i)})
+		}
+
+	default:
+		x, y := interfaceOf(a), interfaceOf(b)
+		if !eq(x, y) {
+			*changes = append(*changes, Change{path, Changed, x, y, keys})
+		}
+	}
+}
+
+// String formats the changes one per line: "+ path: new", "- path: old" or "~ path: old => new".
+// Strings are quoted.
+func (c Changes) String() string {
+	var b strings.Builder
+	for _, change := range c {
+		switch change.Kind {
+		case Added:
+			fmt.Fprintf(&b, "+ %s: %s\n", change.Path, formatValue(change.New))
+		case Removed:
+			fmt.Fprintf(&b, "- %s: %s\n", change.Path, formatValue(change.Old))
+		default:
+			fmt.Fprintf(&b, "~ %s: %s => %s\n", change.Path, formatValue(change.Old), formatValue(change.New))
+		}
+	}
+	return b.String()
+}
+
+// Patch applies changes, as returned by Diff(m, b, eq), to m so that it becomes equal to b.
+// Before each change, the current value at its path is checked against Old with reflect.DeepEqual.
+// It returns an error wrapping ErrPatch at the first change that does not apply; the previous changes stay applied.
+// Nested maps and slices of m are modified in place, so a change adding a key to a nil map does not apply.
+func Patch[M ~map[K]V, K comparable, V any](m M, changes Changes) error {
+	for _, change := range changes {
+		if len(change.keys) == 0 {
+			return fmt.Errorf("%w: empty path", ErrPatch)
+		}
+		if _, err := applyChange(reflect.ValueOf(m), change.keys, change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyChange applies change to the container at the remaining keys and returns the updated container.
+func applyChange(container reflect.Value, keys []any, change Change) (reflect.Value, error) {
+	container = unwrap(container)
+	fail := func(reason string) (reflect.Value, error) {
+		return container, fmt.Errorf("%w: %s %s", ErrPatch, change.Path, reason)
+	}
+
+	switch container.Kind() {
+	case reflect.Map:
+		key := reflect.ValueOf(keys[0])
+		if !key.Type().AssignableTo(container.Type().Key()) {
+			return fail("has a key of the wrong type")
+		}
+		current := container.MapIndex(key)
+		if len(keys) > 1 {
+			if !current.IsValid() {
+				return fail("does not exist")
+			}
+			updated, err := applyChange(current, keys[1:], change)
+			if err == nil {
+				container.SetMapIndex(key, updated)
+			}
+			return container, err
+		}
+		switch {
+		case change.Kind == Added && current.IsValid():
+			return fail("already exists")
+		case change.Kind != Added && (!current.IsValid() || !reflect.DeepEqual(current.Interface(), change.Old)):
+			return fail("does not hold the old value")
+		case change.Kind == Removed:
+			container.SetMapIndex(key, reflect.Value{})
+			return container, nil
+		}
+		if container.IsNil() {
+			return fail("is in a nil map")
+		}
+		value, ok := valueOf(change.New, container.Type().Elem())
+		if !ok {
+			return fail("cannot hold the new value")
+		}
+		container.SetMapIndex(key, value)
+		return container, nil
+
+	case reflect.Slice:
+		i, ok := keys[0].(int)
+		if !ok || i < 0 || i > container.Len() || (i == container.Len() && (len(keys) > 1 || change.Kind != Added)) {
+			return fail("is out of range")
+		}
+		if len(keys) > 1 {
+			updated, err := applyChange(container.Index(i), keys[1:], change)
+			if err == nil {
+				container.Index(i).Set(updated)
+			}
+			return container, err
+		}
+		switch {
+		case change.Kind == Added && i != container.Len():
+			return fail("already exists")
+		case change.Kind != Added && !reflect.DeepEqual(container.Index(i).Interface(), change.Old):
+			return fail("does not hold the old value")
+		case change.Kind == Removed && i != container.Len()-1:
+			return fail("is not the last element")
+		case change.Kind == Removed:
+			return container.Slice(0, i), nil
+		}
+		value, ok := valueOf(change.New, container.Type().Elem())
+		if !ok {
+			return fail("cannot hold the new value")
+		}
+		if change.Kind == Added {
+			return reflect.Append(container, value), nil
+		}
+		container.Index(i).Set(value)
+		return container, nil
+	}
+	return fail("is not in a map or a slice")
+}
+
+// keysByName sorts map keys by their string form.
+type keysByName struct {
+	keys  []reflect.Value
+	names []string
+}
+
+func (k keysByName) Len() int           { return len(k.keys) }
+func (k keysByName) Less(i, j int) bool { return k.names[i] < k.names[j] }
+func (k keysByName) Swap(i, j int) {
+	k.keys[i], k.keys[j] = k.keys[j], k.keys[i]
+	k.names[i], k.names[j] = k.names[j], k.names[i]
+}
+
+// mapIndex returns the value of key in the map m, or an invalid value if key is missing or cannot be a key of m.
+func mapIndex(m, key reflect.Value) reflect.Value {
+	if !key.Type().AssignableTo(m.Type().Key()) {
+		return reflect.Value{}
+	}
+	return m.MapIndex(key)
+}
+
+// unwrap returns the value held by an interface value.
+func unwrap(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v
+}
+
+// isContainer reports whether v is a non-nil map or slice of the given kind, []byte excepted.
+func isContainer(v reflect.Value, kind reflect.Kind) bool {
+	if v.Kind() != kind || v.IsNil() {
+		return false
+	}
+	return kind != reflect.Slice || v.Type().Elem().Kind() != reflect.Uint8
+}
+
+// interfaceOf returns the value held by v, or nil if v is invalid or a nil interface.
+func interfaceOf(v reflect.Value) any {
+	if !v.IsValid() || (v.Kind() == reflect.Interface && v.IsNil()) {
+		return nil
+	}
+	return v.Interface()
+}
+
+// valueOf converts x to a reflect.Value of type t, nil being the zero value.
+func valueOf(x any, t reflect.Type) (reflect.Value, bool) {
+	if x == nil {
+		return reflect.Zero(t), true
+	}
+	v := reflect.ValueOf(x)
+	if !v.Type().AssignableTo(t) {
+		return v, false
+	}
+	return v, true
+}
+
+// appendKey returns a new slice with key added, never sharing its backing array with keys.
+func appendKey(keys []any, key any) []any {
+	return append(keys[:len(keys):len(keys)], key)
+}
+
+// formatValue formats a value for a change line, quoting strings.
+func formatValue(value any) string {
+	if s, ok := value.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprintf("%v", value)
+}
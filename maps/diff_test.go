@@ -0,0 +1,111 @@
+package maps_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/cramanan/go-types/maps"
+	"github.com/cramanan/go-types/slices"
+)
+
+func newConfigs() (old, new map[string]any) {
+	old = map[string]any{
+		"name":  "app",
+		"debug": false,
+		"ports": slices.Slice[int]{80, 443, 8080},
+		"db":    Map[string, any]{"host": "localhost", "pool": 10},
+		"users": []any{map[string]any{"name": "ann"}},
+		"blob":  []byte("ab"),
+	}
+	new = map[string]any{
+		"name":  "app",
+		"debug": true,
+		"ports": slices.Slice[int]{80, 8443},
+		"db":    Map[string, any]{"host": "db.local", "pool": 10, "ssl": true},
+		"users": []any{map[string]any{"name": "bob"}, "guest"},
+		"blob":  []byte("abc"),
+		"tags":  nil,
+	}
+	return old, new
+}
+
+func TestDiff(t *testing.T) {
+	old, new := newConfigs()
+	changes := Diff(old, new, nil)
+
+	want := strings.Join([]string{
+		`~ blob: [97 98] => [97 98 99]`,
+		`~ db.host: "localhost" => "db.local"`,
+		`+ db.ssl: true`,
+		`~ debug: false => true`,
+		`~ ports.1: 443 => 8443`,
+		`- ports.2: 8080`,
+		`+ tags: <nil>`,
+		`~ users.0.name: "ann" => "bob"`,
+		`+ users.1: "guest"`,
+		``,
+	}, "\n")
+	if got := changes.String(); got != want {
+		t.Errorf("Diff got\n%s\nwant\n%s", got, want)
+	}
+	if got := changes[1]; !reflect.DeepEqual(got.Path, Path{"db", "host"}) || got.Kind != Changed || got.Old != "localhost" || got.New != "db.local" {
+		t.Errorf("Diff got change %+v", got)
+	}
+
+	if changes := Diff(old, old, nil); len(changes) != 0 {
+		t.Errorf("Diff of equal maps got %v", changes)
+	}
+	if changes := Diff(nil, map[int]int{1: 2}, nil); changes.String() != "+ 1: 2\n" {
+		t.Errorf("Diff from nil got %v", changes)
+	}
+
+	caseInsensitive := func(x, y any) bool {
+		s1, ok1 := x.(string)
+		s2, ok2 := y.(string)
+		if ok1 && ok2 {
+			return strings.EqualFold(s1, s2)
+		}
+		return reflect.DeepEqual(x, y)
+	}
+	mixed := Diff(map[string]any{"m": map[any]int{1: 1, "1": 2}}, map[string]any{"m": map[any]int{1: 3, "1": 4}}, nil)
+	if got, want := mixed.String(), "~ m.1: 1 => 3\n~ m.1: 2 => 4\n"; got != want && got != "~ m.1: 2 => 4\n~ m.1: 1 => 3\n" {
+		t.Errorf("Diff with keys printing the same got\n%s\nwant\n%s", got, want)
+	}
+
+	if changes := Diff(map[string]string{"a": "X"}, map[string]string{"a": "x"}, caseInsensitive); len(changes) != 0 {
+		t.Errorf("Diff with eq got %v", changes)
+	}
+}
+
+func TestPatch(t *testing.T) {
+	old, new := newConfigs()
+	changes := Diff(old, new, nil)
+	if err := Patch(old, changes); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(old, new) {
+		t.Errorf("Patch got %v, want %v", old, new)
+	}
+
+	// Applying the same changes again fails: the old values are gone.
+	err := Patch(old, changes)
+	if !errors.Is(err, ErrPatch) || err.Error() != "maps: patch does not apply: blob does not hold the old value" {
+		t.Errorf("Patch twice got error %v", err)
+	}
+
+	if err := Patch(map[string]int(nil), Diff(nil, map[string]int{"a": 1}, nil)); !errors.Is(err, ErrPatch) {
+		t.Errorf("Patch adding to a nil map got error %v", err)
+	}
+	mixed := map[any]int{1: 1, "1": 2}
+	doc := map[string]any{"m": mixed}
+	if err := Patch(doc, Diff(doc, map[string]any{"m": map[any]int{1: 3, "1": 4}}, nil)); err != nil || mixed[1] != 3 || mixed["1"] != 4 {
+		t.Errorf("Patch with keys printing the same got %v, %v", mixed, err)
+	}
+
+	wrongType := Diff(map[string]any{"a": 1}, map[string]any{"a": "x"}, nil)
+	if err := Patch(map[string]int{"a": 1}, wrongType); !errors.Is(err, ErrPatch) {
+		t.Errorf("Patch with a value of the wrong type got error %v", err)
+	}
+}
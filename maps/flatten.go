@@ -0,0 +1,210 @@
+package maps
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FlattenOptions configures [FlattenOptions.Flatten] and [FlattenOptions.Unflatten].
+type FlattenOptions struct {
+	// Separator joins the keys of nested values. The default is ".".
+	Separator string
+
+	// Escape escapes backslashes and separators in keys with a backslash, so that a key holding the separator
+	// survives the round trip. Without it, such a key is split into nested keys by Unflatten.
+	Escape bool
+
+	// MaxDepth is the maximum number of keys joined into a flattened key; deeper maps and slices are kept as values.
+	// Zero means no limit.
+	MaxDepth int
+}
+
+// Flatten returns a new map with the nested maps and slices of m replaced by their leaves,
+// under keys joined with sep: {"a": {"b": [1, 2]}} becomes {"a.b.0": 1, "a.b.1": 2}.
+// See [FlattenOptions.Flatten].
+//
+// Example:
+//
+//	flat, _ := Flatten(config, "_")
+//	for key, value := range flat {
+//		env = append(env, fmt.Sprintf("%s=%v", strings.ToUpper(key), value))
+//	}
+func Flatten(m map[string]any, sep string) (map[string]any, error) {
+	return FlattenOptions{Separator: sep}.Flatten(m)
+}
+
+// Unflatten rebuilds the nested maps and slices of a map returned by Flatten(m, sep).
+// See [FlattenOptions.Unflatten].
+func Unflatten(m map[string]any, sep string) (map[string]any, error) {
+	return FlattenOptions{Separator: sep}.Unflatten(m)
+}
+
+// Flatten returns a new map with the nested maps and slices of m replaced by their leaves.
+//
+// Nested maps are maps with string keys, including [Map], and slice elements are keyed by their index.
+// Empty maps and slices are kept as values, as are []byte.
+// It returns an error wrapping ErrKeyCollision if two leaves get the same key,
+// which can only happen if a key holds the separator and Escape is not set.
+func (o FlattenOptions) Flatten(m map[string]any) (map[string]any, error) {
+	flat := make(map[string]any, len(m))
+	if err := o.flatten(reflect.ValueOf(m), "", 0, flat); err != nil {
+		return nil, err
+	}
+	return flat, nil
+}
+
+// flatten adds the leaves of v, found at depth under prefix, to flat.
+func (o FlattenOptions) flatten(v reflect.Value, prefix string, depth int, flat map[string]any) error {
+	v = unwrap(v)
+	join := func(key string) string {
+		if o.Escape {
+			key = strings.ReplaceAll(key, `\`, `\\`)
+			key = strings.ReplaceAll(key, o.separator(), `\`+o.separator())
+		}
+		if depth == 0 {
+			return key
+		}
+		return prefix + o.separator() + key
+	}
+	nested := depth == 0 || o.MaxDepth <= 0 || depth < o.MaxDepth
+
+	switch {
+	case nested && isContainer(v, reflect.Map) && v.Type().Key().Kind() == reflect.String && v.Len() > 0:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, key := range keys {
+			if err := o.flatten(v.MapIndex(key), join(key.String()), depth+1, flat); err != nil {
+				return err
+			}
+		}
+	case nested && isContainer(v, reflect.Slice) && v.Len() > 0:
+		for i := 0; i < v.Len(); i++ {
+			if err := o.flatten(v.Index(i), join(strconv.Itoa(i)), depth+1, flat); err != nil {
+				return err
+			}
+		}
+	case depth == 0:
+		// The top-level map is empty.
+	default:
+		if _, found := flat[prefix]; found {
+			return fmt.Errorf("%w: %s", ErrKeyCollision, prefix)
+		}
+		flat[prefix] = interfaceOf(v)
+	}
+	return nil
+}
+
+// flatNode is a map built by Unflatten, as opposed to a map value.
+type flatNode map[string]any
+
+// Unflatten rebuilds the nested values of a flattened map: keys are split on the separator,
+// unescaping them if Escape is set, into nested map[string]any.
+// A map whose keys are exactly the indices 0 to n-1 becomes a []any of length n,
+// so a map with such keys does not survive the round trip.
+// It returns an error wrapping ErrKeyCollision if a key is both a leaf and the prefix of another key, like "a" and "a.b".
+func (o FlattenOptions) Unflatten(m map[string]any) (map[string]any, error) {
+	keys := Keys(m)
+	sort.Strings(keys)
+	root := flatNode{}
+	for _, key := range keys {
+		node := root
+		parts := o.split(key)
+		for i, part := range parts[:len(parts)-1] {
+			child, found := node[part]
+			if !found {
+				child = flatNode{}
+				node[part] = child
+			}
+			next, ok := child.(flatNode)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s is a value and a prefix of %s", ErrKeyCollision, o.join(parts[:i+1]), key)
+			}
+			node = next
+		}
+		last := parts[len(parts)-1]
+		if _, found := node[last]; found {
+			return nil, fmt.Errorf("%w: %s", ErrKeyCollision, key)
+		}
+		node[last] = m[key]
+	}
+	unflattened := make(map[string]any, len(root))
+	for key, value := range root {
+		unflattened[key] = rebuild(value)
+	}
+	return unflattened, nil
+}
+
+// rebuild turns the flatNodes of value into maps, or slices if their keys are indices.
+func rebuild(value any) any {
+	node, ok := value.(flatNode)
+	if !ok {
+		return value
+	}
+	if slice, ok := asIndices(node); ok {
+		for i, element := range slice {
+			slice[i] = rebuild(element)
+		}
+		return slice
+	}
+	m := make(map[string]any, len(node))
+	for key, element := range node {
+		m[key] = rebuild(element)
+	}
+	return m
+}
+
+// asIndices returns the values of node as a slice if its keys are the indices 0 to len(node)-1, without leading zeros.
+func asIndices(node flatNode) ([]any, bool) {
+	slice := make([]any, len(node))
+	for key, value := range node {
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i >= len(slice) || strconv.Itoa(i) != key {
+			return nil, false
+		}
+		slice[i] = value
+	}
+	return slice, true
+}
+
+// split splits a flattened key on the separator, unescaping its parts if Escape is set.
+func (o FlattenOptions) split(key string) []string {
+	sep := o.separator()
+	if !o.Escape {
+		return strings.Split(key, sep)
+	}
+	var parts []string
+	var part strings.Builder
+	for i := 0; i < len(key); {
+		switch {
+		case key[i] == '\\' && i+1 < len(key):
+			if strings.HasPrefix(key[i+1:], sep) {
+				part.WriteString(sep)
+				i += 1 + len(sep)
+			} else {
+				part.WriteByte(key[i+1])
+				i += 2
+			}
+		case strings.HasPrefix(key[i:], sep):
+			parts = append(parts, part.String())
+			part.Reset()
+			i += len(sep)
+		default:
+			part.WriteByte(key[i])
+			i++
+		}
+	}
+	return append(parts, part.String())
+}
+
+// join joins key parts with the separator, for error messages.
+func (o FlattenOptions) join(parts []string) string { return strings.Join(parts, o.separator()) }
+
+func (o FlattenOptions) separator() string {
+	if o.Separator == "" {
+		return "."
+	}
+	return o.Separator
+}
@@ -0,0 +1,115 @@
+package maps_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	. "github.com/cramanan/go-types/maps"
+)
+
+func TestFlatten(t *testing.T) {
+	doc := map[string]any{
+		"db":    Map[string, any]{"host": "localhost", "ports": []int{5432, 5433}},
+		"users": []any{map[string]any{"name": "ann"}, "guest"},
+		"empty": map[string]any{},
+		"a.b":   1,
+	}
+	flat, _ := Flatten(doc, "_")
+	escaped, _ := FlattenOptions{Escape: true}.Flatten(doc)
+	shallow, _ := FlattenOptions{MaxDepth: 2}.Flatten(doc)
+	_, collision := Flatten(map[string]any{"a.b": 1, "a": map[string]any{"b": 2}}, ".")
+
+	testCases := []struct {
+		desc      string
+		got, want any
+	}{
+		{"Flatten", flat, map[string]any{
+			"db_host": "localhost", "db_ports_0": 5432, "db_ports_1": 5433,
+			"users_0_name": "ann", "users_1": "guest", "empty": map[string]any{}, "a.b": 1,
+		}},
+		{"Escape", escaped, map[string]any{
+			"db.host": "localhost", "db.ports.0": 5432, "db.ports.1": 5433,
+			"users.0.name": "ann", "users.1": "guest", "empty": map[string]any{}, `a\.b`: 1,
+		}},
+		{"MaxDepth", shallow, map[string]any{
+			"db.host": "localhost", "db.ports": []int{5432, 5433},
+			"users.0": map[string]any{"name": "ann"}, "users.1": "guest", "empty": map[string]any{}, "a.b": 1,
+		}},
+		{"collision", errors.Is(collision, ErrKeyCollision), true},
+		{"empty", flattenOrNil(map[string]any{}), map[string]any{}},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !reflect.DeepEqual(tC.got, tC.want) {
+				t.Errorf("got %v, want %v", tC.got, tC.want)
+			}
+		})
+	}
+}
+
+func flattenOrNil(m map[string]any) map[string]any {
+	flat, _ := Flatten(m, ".")
+	return flat
+}
+
+func TestUnflatten(t *testing.T) {
+	nested, _ := Unflatten(map[string]any{"a.0": 1, "a.1.b": 2, "c.00": 3, "c.1": 4, "d.1": 5}, ".")
+	_, prefix := Unflatten(map[string]any{"a": 1, "a.b": 2}, ".")
+	escaped, _ := FlattenOptions{Separator: "::", Escape: true}.Unflatten(map[string]any{`a\::b::c`: 1, `x\\::y`: 2})
+
+	testCases := []struct {
+		desc      string
+		got, want any
+	}{
+		{"indices", nested, map[string]any{
+			"a": []any{1, map[string]any{"b": 2}},
+			"c": map[string]any{"00": 3, "1": 4},
+			"d": map[string]any{"1": 5},
+		}},
+		{"prefix collision", errors.Is(prefix, ErrKeyCollision), true},
+		{"escaped", escaped, map[string]any{"a::b": map[string]any{"c": 1}, `x\`: map[string]any{"y": 2}}},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !reflect.DeepEqual(tC.got, tC.want) {
+				t.Errorf("got %v, want %v", tC.got, tC.want)
+			}
+		})
+	}
+}
+
+func TestFlattenRoundTrip(t *testing.T) {
+	var doc map[string]any
+	err := json.Unmarshal([]byte(`{
+		"name": "app",
+		"server": {"host": "0.0.0.0", "port": 8080, "tls": null},
+		"routes": [{"path": "/", "methods": ["GET", "HEAD"]}, {"path": "/api", "methods": []}],
+		"labels": {"app.kubernetes.io/name": "app", "tier\\x": "web"},
+		"limits": {}
+	}`), &doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, options := range []FlattenOptions{
+		{Escape: true},
+		{Separator: "__", Escape: true},
+		{Escape: true, MaxDepth: 2},
+	} {
+		flat, err := options.Flatten(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := options.Unflatten(flat)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, doc) {
+			t.Errorf("%+v: round trip got %v, want %v", options, got, doc)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package maps
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrTypeMismatch is returned by [Merge] when a source value and a destination value at the same path
+// have different types and no resolver settles the conflict.
+var ErrTypeMismatch = errors.New("maps: type mismatch")
+
+// Path is the sequence of keys leading to a value in nested maps, from the root.
+type Path []string
+
+// String returns the keys of the path joined by dots, e.g. "server.tls.port".
+func (p Path) String() string { return strings.Join(p, ".") }
+
+// append returns a new path with key added, never sharing its backing array with p.
+func (p Path) append(key string) Path {
+	path := make(Path, len(p)+1)
+	copy(path, p)
+	path[len(p)] = key
+	return path
+}
+
+// SliceStrategy defines how [Merge] combines two slices found at the same path.
+type SliceStrategy int
+
+const (
+	// ReplaceSlices replaces the destination slice by the source slice.
+	ReplaceSlices SliceStrategy = iota
+
+	// AppendSlices appends the elements of the source slice to the destination slice.
+	// Both slices must have the same type.
+	AppendSlices
+)
+
+// MergeOptions configures [MergeOptions.Merge]. The zero value replaces slices and has no resolver.
+type MergeOptions struct {
+	// Slices defines how slices found at the same path are combined.
+	Slices SliceStrategy
+
+	// Resolve, if not nil, settles every collision between a destination and a source value
+	// that are not both maps: it returns the value to keep at path. Returning an error stops the merge.
+	// When Resolve is nil, the source value wins, and values of different types are an error.
+	Resolve func(path Path, dst, src any) (any, error)
+}
+
+// MergeReport describes what a merge changed.
+type MergeReport struct {
+	// Overridden holds the paths where a value of the destination was replaced or resolved, in merge order.
+	Overridden []Path
+}
+
+// Merge recursively merges every source into dst, in order, with the default MergeOptions:
+// nested maps are merged key by key, any other source value replaces the destination value.
+// See [MergeOptions.Merge].
+//
+// Example:
+//
+//	config := map[string]any{}
+//	report, err := Merge(config, defaults, fromFile, fromEnv)
+func Merge(dst map[string]any, srcs ...map[string]any) (MergeReport, error) {
+	return MergeOptions{}.Merge(dst, srcs...)
+}
+
+// Merge recursively merges every source into dst, in order, and reports the overridden paths.
+//
+// Values of type map[string]any or Map[string, any] are nested maps, merged key by key.
+// Maps and slices taken from a source, of any type and at any depth, are copied, so dst never shares them with the sources.
+// Keys are merged in sorted order, so the report and the errors are deterministic.
+//
+// If an error is returned, dst may be partially merged.
+func (o MergeOptions) Merge(dst map[string]any, srcs ...map[string]any) (report MergeReport, err error) {
+	for _, src := range srcs {
+		if err = o.merge(dst, src, nil, &report); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// merge merges src into dst, the nested map at path.
+func (o MergeOptions) merge(dst, src map[string]any, path Path, report *MergeReport) error {
+	keys := Keys(src)
+	sort.Strings(keys)
+	for _, key := range keys {
+		s := src[key]
+		d, found := dst[key]
+		if !found {
+			dst[key] = deepCopy(s)
+			continue
+		}
+
+		keyPath := path.append(key)
+		dMap, dIsMap := asMap(d)
+		sMap, sIsMap := asMap(s)
+		if dIsMap && sIsMap {
+			if err := o.merge(dMap, sMap, keyPath, report); err != nil {
+				return err
+			}
+			continue
+		}
+
+		merged, err := o.combine(keyPath, d, s)
+		if err != nil {
+			return err
+		}
+		dst[key] = merged
+		report.Overridden = append(report.Overridden, keyPath)
+	}
+	return nil
+}
+
+// combine returns the value to keep at path when d and s collide.
+func (o MergeOptions) combine(path Path, d, s any) (any, error) {
+	if o.Resolve != nil {
+		return o.Resolve(path, d, s)
+	}
+	dType, sType := reflect.TypeOf(d), reflect.TypeOf(s)
+	if d != nil && s != nil && dType != sType {
+		return nil, fmt.Errorf("%w at %s: %T and %T", ErrTypeMismatch, path, d, s)
+	}
+	if o.Slices == AppendSlices && sType != nil && sType.Kind() == reflect.Slice {
+		dValue, sValue := reflect.ValueOf(d), reflect.ValueOf(s)
+		if dType == nil {
+			return deepCopy(s), nil
+		}
+		merged := reflect.MakeSlice(sType, 0, dValue.Len()+sValue.Len())
+		return reflect.AppendSlice(reflect.AppendSlice(merged, dValue), reflect.ValueOf(deepCopy(s))).Interface(), nil
+	}
+	return deepCopy(s), nil
+}
+
+// asMap returns v as a map[string]any if it is a nested map.
+func asMap(v any) (map[string]any, bool) {
+	switch m := v.(type) {
+	case map[string]any:
+		return m, m != nil
+	case Map[string, any]:
+		return m, m != nil
+	}
+	return nil, false
+}
+
+// deepCopy copies maps, slices and arrays recursively, whatever their types. Other values are returned as is.
+func deepCopy(v any) any {
+	if v == nil {
+		return nil
+	}
+	return deepCopyValue(reflect.ValueOf(v)).Interface()
+}
+
+func deepCopyValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		clone := reflect.New(v.Type()).Elem()
+		clone.Set(deepCopyValue(v.Elem()))
+		return clone
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		clone := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			clone.SetMapIndex(iter.Key(), deepCopyValue(iter.Value()))
+		}
+		return clone
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		clone := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			clone.Index(i).Set(deepCopyValue(v.Index(i)))
+		}
+		return clone
+	case reflect.Array:
+		clone := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			clone.Index(i).Set(deepCopyValue(v.Index(i)))
+		}
+		return clone
+	}
+	return v
+}
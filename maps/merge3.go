@@ -0,0 +1,121 @@
+package maps
+
+// ConflictKind describes how both sides of a [Merge3] disagree on a key.
+type ConflictKind int
+
+const (
+	// BothModified means both sides changed the value of the key differently.
+	BothModified ConflictKind = iota
+
+	// BothAdded means the key was not in the base and both sides added it with different values.
+	BothAdded
+
+	// DeletedByOurs means ours deleted the key while theirs modified it.
+	DeletedByOurs
+
+	// DeletedByTheirs means theirs deleted the key while ours modified it.
+	DeletedByTheirs
+)
+
+// String returns the name of the conflict kind.
+func (k ConflictKind) String() string {
+	switch k {
+	case BothModified:
+		return "both modified"
+	case BothAdded:
+		return "both added"
+	case DeletedByOurs:
+		return "deleted by ours"
+	case DeletedByTheirs:
+		return "deleted by theirs"
+	}
+	return "unknown conflict"
+}
+
+// Conflict is a key that both sides of a [Merge3] changed in incompatible ways.
+// The values that are absent according to Kind (Base for BothAdded, Ours for DeletedByOurs,
+// Theirs for DeletedByTheirs) are zero.
+type Conflict[K comparable, V any] struct {
+	Key                K
+	Kind               ConflictKind
+	Base, Ours, Theirs V
+}
+
+// Merge3 is a three-way merge: it applies to base the changes made by ours and by theirs, two versions derived from it.
+// Values are compared using ==. See [Merge3Func].
+//
+// Example:
+//
+//	merged, conflicts := Merge3(
+//		map[string]string{"theme": "light", "lang": "en"},
+//		map[string]string{"theme": "dark", "lang": "en"},
+//		map[string]string{"theme": "light", "lang": "fr"},
+//	)
+//	// merged: map[lang:fr theme:dark], no conflicts
+func Merge3[M ~map[K]V, K comparable, V comparable](base, ours, theirs M) (M, []Conflict[K, V]) {
+	return Merge3Func(base, ours, theirs, func(v1, v2 V) bool { return v1 == v2 })
+}
+
+// Merge3Func is like Merge3, but compares values using eq.
+//
+// A key changed (added, modified or deleted) on one side only takes the value of that side.
+// A key changed the same way on both sides takes that value.
+// A key changed differently on both sides is a conflict: it keeps its base value, or stays absent if it was not
+// in the base, and is reported in the returned conflicts, in an indeterminate order.
+// None of the maps is modified.
+func Merge3Func[M ~map[K]V, K comparable, V any](base, ours, theirs M, eq func(V, V) bool) (merged M, conflicts []Conflict[K, V]) {
+	if eq == nil {
+		panic("callback function is nil")
+	}
+	same := func(v1 V, found1 bool, v2 V, found2 bool) bool {
+		return found1 == found2 && (!found1 || eq(v1, v2))
+	}
+
+	merged = make(M, len(base))
+	visit := func(key K) {
+		b, inBase := base[key]
+		o, inOurs := ours[key]
+		t, inTheirs := theirs[key]
+		oursChanged := !same(b, inBase, o, inOurs)
+		theirsChanged := !same(b, inBase, t, inTheirs)
+
+		value, found := b, inBase
+		switch {
+		case oursChanged && theirsChanged && !same(o, inOurs, t, inTheirs):
+			conflict := Conflict[K, V]{Key: key, Base: b, Ours: o, Theirs: t}
+			switch {
+			case !inBase:
+				conflict.Kind = BothAdded
+			case !inOurs:
+				conflict.Kind = DeletedByOurs
+			case !inTheirs:
+				conflict.Kind = DeletedByTheirs
+			}
+			conflicts = append(conflicts, conflict)
+		case oursChanged:
+			value, found = o, inOurs
+		case theirsChanged:
+			value, found = t, inTheirs
+		}
+		if found {
+			merged[key] = value
+		}
+	}
+
+	for key := range base {
+		visit(key)
+	}
+	for key := range ours {
+		if _, inBase := base[key]; !inBase {
+			visit(key)
+		}
+	}
+	for key := range theirs {
+		_, inBase := base[key]
+		_, inOurs := ours[key]
+		if !inBase && !inOurs {
+			visit(key)
+		}
+	}
+	return merged, conflicts
+}
@@ -0,0 +1,48 @@
+package maps_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	. "github.com/cramanan/go-types/maps"
+)
+
+func TestMerge3(t *testing.T) {
+	base := Map[string, int]{"same": 1, "ours": 1, "theirs": 1, "both": 1, "conflict": 1, "delOurs": 1, "delTheirs": 1, "delBoth": 1, "delMod": 1}
+	ours := Map[string, int]{"same": 1, "ours": 2, "theirs": 1, "both": 3, "conflict": 4, "delTheirs": 5, "delMod": 1, "addOurs": 1, "added": 1}
+	theirs := Map[string, int]{"same": 1, "ours": 1, "theirs": 2, "both": 3, "conflict": 5, "delOurs": 6, "addTheirs": 1, "added": 2}
+
+	merged, conflicts := Merge3(base, ours, theirs)
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Key < conflicts[j].Key })
+
+	wantMerged := Map[string, int]{"same": 1, "ours": 2, "theirs": 2, "both": 3, "conflict": 1, "delOurs": 1, "delTheirs": 1, "addOurs": 1, "addTheirs": 1}
+	wantConflicts := []Conflict[string, int]{
+		{Key: "added", Kind: BothAdded, Ours: 1, Theirs: 2},
+		{Key: "conflict", Kind: BothModified, Base: 1, Ours: 4, Theirs: 5},
+		{Key: "delOurs", Kind: DeletedByOurs, Base: 1, Theirs: 6},
+		{Key: "delTheirs", Kind: DeletedByTheirs, Base: 1, Ours: 5},
+	}
+
+	if !Equal(merged, wantMerged) {
+		t.Errorf("Merge3 got %v, want %v", merged, wantMerged)
+	}
+	if !reflect.DeepEqual(conflicts, wantConflicts) {
+		t.Errorf("Merge3 conflicts got %v, want %v", conflicts, wantConflicts)
+	}
+}
+
+func TestMerge3Func(t *testing.T) {
+	base := map[string][]string{"langs": {"en"}}
+	ours := map[string][]string{"langs": {"en", "fr"}}
+	theirs := map[string][]string{"langs": {"en", "fr"}, "extra": nil}
+
+	merged, conflicts := Merge3Func(base, ours, theirs, func(a, b []string) bool { return reflect.DeepEqual(a, b) })
+	want := map[string][]string{"langs": {"en", "fr"}, "extra": nil}
+	if !reflect.DeepEqual(merged, want) || len(conflicts) != 0 {
+		t.Errorf("Merge3Func got %v and %v, want %v and no conflict", merged, conflicts, want)
+	}
+	if got := DeletedByTheirs.String(); got != "deleted by theirs" {
+		t.Errorf("String() got %q", got)
+	}
+}
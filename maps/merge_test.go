@@ -0,0 +1,134 @@
+package maps_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	. "github.com/cramanan/go-types/maps"
+)
+
+func TestMerge(t *testing.T) {
+	defaults := map[string]any{
+		"name": "app",
+		"server": map[string]any{
+			"port":  8080,
+			"hosts": []string{"a"},
+			"tls":   Map[string, any]{"enabled": false},
+		},
+	}
+	file := map[string]any{
+		"server": Map[string, any]{
+			"port":  9090,
+			"hosts": []string{"b", "c"},
+			"tls":   map[string]any{"enabled": true, "cert": "x.pem"},
+		},
+		"debug": nil,
+	}
+	env := map[string]any{"debug": true, "name": "prod"}
+
+	dst := map[string]any{}
+	report, err := Merge(dst, defaults, file, env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"name":  "prod",
+		"debug": true,
+		"server": map[string]any{
+			"port":  9090,
+			"hosts": []string{"b", "c"},
+			"tls":   Map[string, any]{"enabled": true, "cert": "x.pem"},
+		},
+	}
+	if !reflect.DeepEqual(dst, want) {
+		t.Errorf("Merge got %v, want %v", dst, want)
+	}
+	wantReport := []Path{{"server", "hosts"}, {"server", "port"}, {"server", "tls", "enabled"}, {"debug"}, {"name"}}
+	if !reflect.DeepEqual(report.Overridden, wantReport) {
+		t.Errorf("Merge report got %v, want %v", report.Overridden, wantReport)
+	}
+	if got := defaults["server"].(map[string]any)["port"]; got != 8080 {
+		t.Errorf("Merge modified a source: got port %v, want 8080", got)
+	}
+
+	newSrc := func() map[string]any {
+		return map[string]any{
+			"items":  []any{map[string]any{"x": 1}, []map[string]any{{"y": 2}}},
+			"counts": map[string]int{"a": 1},
+			"rows":   []map[string]int{{"n": 1}},
+			"grid":   [1][]int{{1}},
+		}
+	}
+	src := newSrc()
+	for _, options := range []MergeOptions{{}, {Slices: AppendSlices}} {
+		dst := map[string]any{}
+		if _, err := options.Merge(dst, src); err != nil {
+			t.Fatal(err)
+		}
+		items := dst["items"].([]any)
+		items[0].(map[string]any)["x"] = 10
+		items[1].([]map[string]any)[0]["y"] = 20
+		dst["counts"].(map[string]int)["a"] = 10
+		dst["rows"].([]map[string]int)[0]["n"] = 10
+		dst["grid"].([1][]int)[0][0] = 10
+		if !reflect.DeepEqual(src, newSrc()) {
+			t.Errorf("Merge shares maps or slices with a source: got %v, want %v", src, newSrc())
+		}
+	}
+}
+
+func TestMergeOptions(t *testing.T) {
+	newDst := func() map[string]any {
+		return map[string]any{"tags": []string{"a"}, "port": 80, "nested": map[string]any{"limit": 1}}
+	}
+	src := map[string]any{"tags": []string{"b"}, "port": "80", "nested": map[string]any{"limit": 5}}
+
+	t.Run("AppendSlices", func(t *testing.T) {
+		dst := newDst()
+		_, err := MergeOptions{Slices: AppendSlices}.Merge(dst, map[string]any{"tags": []string{"b"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := dst["tags"], []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		_, err := Merge(newDst(), src)
+		if !errors.Is(err, ErrTypeMismatch) || err.Error() != "maps: type mismatch at port: int and string" {
+			t.Errorf("got error %v, want ErrTypeMismatch at port", err)
+		}
+	})
+
+	t.Run("Resolve", func(t *testing.T) {
+		dst := newDst()
+		var paths []string
+		options := MergeOptions{Resolve: func(path Path, d, s any) (any, error) {
+			paths = append(paths, path.String())
+			if path.String() == "nested.limit" && d.(int) < s.(int) {
+				return d, nil // keep the lowest limit
+			}
+			return s, nil
+		}}
+		if _, err := options.Merge(dst, src); err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]any{"tags": []string{"b"}, "port": "80", "nested": map[string]any{"limit": 1}}
+		if !reflect.DeepEqual(dst, want) {
+			t.Errorf("got %v, want %v", dst, want)
+		}
+		if want := []string{"nested.limit", "port", "tags"}; !reflect.DeepEqual(paths, want) {
+			t.Errorf("resolved paths got %v, want %v", paths, want)
+		}
+	})
+
+	t.Run("Resolve error", func(t *testing.T) {
+		errNo := errors.New("no")
+		options := MergeOptions{Resolve: func(Path, any, any) (any, error) { return nil, errNo }}
+		if _, err := options.Merge(newDst(), src); err != errNo {
+			t.Errorf("got error %v, want %v", err, errNo)
+		}
+	})
+}
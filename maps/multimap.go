@@ -0,0 +1,188 @@
+package maps
+
+import (
+	"fmt"
+
+	"github.com/cramanan/go-types/functions"
+	"github.com/cramanan/go-types/slices"
+)
+
+// ValueStorage defines how a [MultiMap] stores the values of a key.
+type ValueStorage int
+
+const (
+	// ListStorage keeps every value put for a key, duplicates included, in insertion order.
+	ListStorage ValueStorage = iota
+
+	// SetStorage keeps each value at most once per key, in the order it was first put.
+	SetStorage
+)
+
+// MultiMap maps each key to one or more values.
+// A key is present as long as it has at least one value.
+//
+// The zero value is an empty map with ListStorage, ready to use. A MultiMap is not safe for concurrent use.
+//
+// Example:
+//
+//	tags := NewMultiMap[string, string](SetStorage)
+//	tags.PutAll("post-1", "go", "generics", "go")
+//	tags.Get("post-1") // [go generics]
+type MultiMap[K, V comparable] struct {
+	values  map[K]slices.Slice[V]
+	members map[K]map[V]struct{} // only with SetStorage, for O(1) deduplication
+	storage ValueStorage
+	count   int
+}
+
+// NewMultiMap allocates and initializes an empty MultiMap storing values as defined by storage.
+func NewMultiMap[K, V comparable](storage ValueStorage) *MultiMap[K, V] {
+	return &MultiMap[K, V]{values: make(map[K]slices.Slice[V]), storage: storage}
+}
+
+// Put adds value to the values of key and reports whether it was added.
+// With SetStorage, a value already present for key is not added again.
+func (m *MultiMap[K, V]) Put(key K, value V) bool {
+	if m.values == nil {
+		m.values = make(map[K]slices.Slice[V])
+	}
+	if m.storage == SetStorage {
+		if m.members == nil {
+			m.members = make(map[K]map[V]struct{})
+		}
+		set, ok := m.members[key]
+		if !ok {
+			set = make(map[V]struct{})
+			m.members[key] = set
+		}
+		if _, found := set[value]; found {
+			return false
+		}
+		set[value] = struct{}{}
+	}
+	m.values[key] = append(m.values[key], value)
+	m.count++
+	return true
+}
+
+// PutAll adds every value to the values of key and returns how many were added. See [MultiMap.Put].
+func (m *MultiMap[K, V]) PutAll(key K, values ...V) (added int) {
+	for _, value := range values {
+		if m.Put(key, value) {
+			added++
+		}
+	}
+	return added
+}
+
+// Get returns a copy of the values of key in insertion order, or nil if the key is not present.
+func (m *MultiMap[K, V]) Get(key K) slices.Slice[V] { return m.values[key].Clone() }
+
+// Remove removes the first occurrence of value from the values of key and reports whether it was present.
+// The key is removed with its last value.
+func (m *MultiMap[K, V]) Remove(key K, value V) bool {
+	values := m.values[key]
+	i := slices.Index(values, value)
+	if i < 0 {
+		return false
+	}
+	if len(values) == 1 {
+		m.RemoveAll(key)
+		return true
+	}
+	m.values[key] = slices.Delete(values, i, i+1)
+	if m.storage == SetStorage {
+		delete(m.members[key], value)
+	}
+	m.count--
+	return true
+}
+
+// RemoveAll removes key and returns its values, or nil if the key was not present.
+func (m *MultiMap[K, V]) RemoveAll(key K) slices.Slice[V] {
+	values := m.values[key]
+	delete(m.values, key)
+	delete(m.members, key)
+	m.count -= len(values)
+	return values
+}
+
+// ContainsKey reports whether key has at least one value.
+func (m *MultiMap[K, V]) ContainsKey(key K) bool {
+	_, found := m.values[key]
+	return found
+}
+
+// ContainsEntry reports whether value is one of the values of key.
+func (m *MultiMap[K, V]) ContainsEntry(key K, value V) bool {
+	if m.storage == SetStorage {
+		_, found := m.members[key][value]
+		return found
+	}
+	return slices.Contains(m.values[key], value)
+}
+
+// KeyCount returns the number of distinct keys.
+func (m *MultiMap[K, V]) KeyCount() int { return len(m.values) }
+
+// ValueCount returns the number of values over all keys, which is the number of entries.
+func (m *MultiMap[K, V]) ValueCount() int { return m.count }
+
+// IsEmpty returns true if the map is empty
+func (m *MultiMap[K, V]) IsEmpty() bool { return m.count == 0 }
+
+// Keys returns the distinct keys of the map.
+// The keys will be in an indeterminate order.
+func (m *MultiMap[K, V]) Keys() []K { return Keys(m.values) }
+
+// All returns an iterator over every key/value entry of the map: a key with n values is yielded n times.
+// The keys will be in an indeterminate order; the values of a key are in insertion order.
+// The map must not be modified during the iteration.
+func (m *MultiMap[K, V]) All() functions.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, values := range m.values {
+			for _, value := range values {
+				if !yield(key, value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ForEach executes a provided function once per each key/value entry of the map. See [MultiMap.All].
+func (m *MultiMap[K, V]) ForEach(callbackFn func(K, V)) {
+	if callbackFn == nil {
+		panic("callback function is nil")
+	}
+	m.All()(func(key K, value V) bool {
+		callbackFn(key, value)
+		return true
+	})
+}
+
+// Map returns a copy of the map as a Map of keys to their values.
+func (m *MultiMap[K, V]) Map() Map[K, slices.Slice[V]] {
+	mapped := make(Map[K, slices.Slice[V]], len(m.values))
+	for key, values := range m.values {
+		mapped[key] = values.Clone()
+	}
+	return mapped
+}
+
+// Clone returns a copy of m with the same storage.
+func (m *MultiMap[K, V]) Clone() *MultiMap[K, V] {
+	clone := NewMultiMap[K, V](m.storage)
+	for key, values := range m.values {
+		clone.PutAll(key, values...)
+	}
+	return clone
+}
+
+// Clear removes all entries from m, leaving it empty.
+func (m *MultiMap[K, V]) Clear() {
+	m.values, m.members, m.count = nil, nil, 0
+}
+
+// String formats the map like fmt formats a map of slices: map[k1:[v1 v2] k2:[v3]].
+func (m *MultiMap[K, V]) String() string { return fmt.Sprint(m.values) }
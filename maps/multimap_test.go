@@ -0,0 +1,68 @@
+package maps_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	. "github.com/cramanan/go-types/maps"
+	"github.com/cramanan/go-types/slices"
+)
+
+func TestMultiMap(t *testing.T) {
+	var list MultiMap[string, int]
+	list.PutAll("a", 1, 2, 1)
+	list.Put("b", 3)
+	list.Remove("a", 1)
+	list.Remove("a", 5)
+
+	set := NewMultiMap[string, int](SetStorage)
+	added := set.PutAll("a", 1, 2, 1)
+	set.Put("b", 3)
+	set.Remove("a", 1)
+	set.Put("a", 1)
+	removed := set.RemoveAll("b")
+
+	get := list.Get("a")
+	get[0] = 100
+
+	var entries []string
+	list.ForEach(func(k string, v int) { entries = append(entries, k+string(rune('0'+v))) })
+	sort.Strings(entries)
+
+	testCases := []struct {
+		desc      string
+		got, want any
+	}{
+		{"list Get", list.Get("a"), slices.Slice[int]{2, 1}},
+		{"list Get missing", list.Get("z"), slices.Slice[int](nil)},
+		{"list ContainsEntry", list.ContainsEntry("a", 1), true},
+		{"list ValueCount", list.ValueCount(), 3},
+		{"list KeyCount", list.KeyCount(), 2},
+		{"list entries", entries, []string{"a1", "a2", "b3"}},
+		{"list String", list.String(), "map[a:[2 1] b:[3]]"},
+		{"set PutAll", added, 2},
+		{"set Get", set.Get("a"), slices.Slice[int]{2, 1}},
+		{"set Put duplicate", set.Put("a", 2), false},
+		{"set ContainsEntry", set.ContainsEntry("a", 3), false},
+		{"set RemoveAll", removed, slices.Slice[int]{3}},
+		{"set ContainsKey", set.ContainsKey("b"), false},
+		{"set ValueCount", set.ValueCount(), 2},
+		{"Remove last value", list.Remove("b", 3) && !list.ContainsKey("b"), true},
+		{"Map", set.Map(), Map[string, slices.Slice[int]]{"a": {2, 1}}},
+		{"Clone", set.Clone().Put("a", 1), false},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !reflect.DeepEqual(tC.got, tC.want) {
+				t.Errorf("got %v, want %v", tC.got, tC.want)
+			}
+		})
+	}
+
+	set.Clear()
+	if !set.IsEmpty() || set.KeyCount() != 0 {
+		t.Errorf("Clear() left %v", set)
+	}
+}
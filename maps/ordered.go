@@ -0,0 +1,415 @@
+package maps
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/cramanan/go-types/functions"
+)
+
+// OrderedMap is a map that remembers the order in which its keys were first inserted.
+// Get, Set, Delete, MoveToFront and MoveToBack run in O(1).
+//
+// The zero value is an empty map ready to use. An OrderedMap must not be copied after first use:
+// pass it by pointer. It is not safe for concurrent use.
+type OrderedMap[K comparable, V any] struct {
+	entries     map[K]*orderedEntry[K, V]
+	front, back *orderedEntry[K, V]
+}
+
+// orderedEntry is a node of the doubly linked list holding the insertion order.
+type orderedEntry[K comparable, V any] struct {
+	key        K
+	value      V
+	prev, next *orderedEntry[K, V]
+}
+
+// NewOrderedMap allocates and initializes an empty OrderedMap.
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{entries: make(map[K]*orderedEntry[K, V])}
+}
+
+// Get retrieves the value for a given key from the map.
+// If the key is not present in the map, the second found value is false.
+func (m *OrderedMap[K, V]) Get(key K) (value V, found bool) {
+	if e, ok := m.entries[key]; ok {
+		return e.value, true
+	}
+	return value, false
+}
+
+// Has reports whether key is present in the map.
+func (m *OrderedMap[K, V]) Has(key K) bool {
+	_, found := m.entries[key]
+	return found
+}
+
+// Set sets the value for a given key in the map.
+// A new key is added at the back; an existing key keeps its position and its old value is replaced.
+// The new value is returned.
+func (m *OrderedMap[K, V]) Set(key K, value V) V {
+	if e, ok := m.entries[key]; ok {
+		e.value = value
+		return value
+	}
+	if m.entries == nil {
+		m.entries = make(map[K]*orderedEntry[K, V])
+	}
+	e := &orderedEntry[K, V]{key: key, value: value}
+	m.entries[key] = e
+	m.pushBack(e)
+	return value
+}
+
+// Delete removes key from the map and reports whether it was present.
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	e, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+	delete(m.entries, key)
+	m.unlink(e)
+	return true
+}
+
+// MoveToFront moves key to the front of the map and reports whether it was present.
+func (m *OrderedMap[K, V]) MoveToFront(key K) bool {
+	e, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+	if e != m.front {
+		m.unlink(e)
+		e.next = m.front
+		m.front.prev = e
+		m.front = e
+	}
+	return true
+}
+
+// MoveToBack moves key to the back of the map and reports whether it was present.
+func (m *OrderedMap[K, V]) MoveToBack(key K) bool {
+	e, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+	if e != m.back {
+		m.unlink(e)
+		m.pushBack(e)
+	}
+	return true
+}
+
+// Front returns the first key/value pair of the map. ok is false if the map is empty.
+func (m *OrderedMap[K, V]) Front() (key K, value V, ok bool) {
+	if m.front == nil {
+		return key, value, false
+	}
+	return m.front.key, m.front.value, true
+}
+
+// Back returns the last key/value pair of the map. ok is false if the map is empty.
+func (m *OrderedMap[K, V]) Back() (key K, value V, ok bool) {
+	if m.back == nil {
+		return key, value, false
+	}
+	return m.back.key, m.back.value, true
+}
+
+// pushBack links a detached entry at the back of the list.
+func (m *OrderedMap[K, V]) pushBack(e *orderedEntry[K, V]) {
+	e.prev, e.next = m.back, nil
+	if m.back == nil {
+		m.front = e
+	} else {
+		m.back.next = e
+	}
+	m.back = e
+}
+
+// unlink detaches an entry from the list.
+func (m *OrderedMap[K, V]) unlink(e *orderedEntry[K, V]) {
+	if e.prev == nil {
+		m.front = e.next
+	} else {
+		e.prev.next = e.next
+	}
+	if e.next == nil {
+		m.back = e.prev
+	} else {
+		e.next.prev = e.prev
+	}
+	e.prev, e.next = nil, nil
+}
+
+// All returns an iterator over the key/value pairs of the map, from front to back.
+// The map must not be modified during the iteration, except by deleting the current key.
+func (m *OrderedMap[K, V]) All() functions.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := m.front; e != nil; {
+			next := e.next
+			if !yield(e.key, e.value) {
+				return
+			}
+			e = next
+		}
+	}
+}
+
+// Backward returns an iterator over the key/value pairs of the map, from back to front.
+// The map must not be modified during the iteration, except by deleting the current key.
+func (m *OrderedMap[K, V]) Backward() functions.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := m.back; e != nil; {
+			prev := e.prev
+			if !yield(e.key, e.value) {
+				return
+			}
+			e = prev
+		}
+	}
+}
+
+// Keys returns the keys of the map in insertion order.
+func (m *OrderedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(m.entries))
+	for e := m.front; e != nil; e = e.next {
+		keys = append(keys, e.key)
+	}
+	return keys
+}
+
+// Values returns the values of the map in insertion order.
+func (m *OrderedMap[K, V]) Values() []V {
+	values := make([]V, 0, len(m.entries))
+	for e := m.front; e != nil; e = e.next {
+		values = append(values, e.value)
+	}
+	return values
+}
+
+// Clone returns a copy of m with the same order. This is a shallow clone:
+// the new keys and values are set using ordinary assignment.
+func (m *OrderedMap[K, V]) Clone() *OrderedMap[K, V] {
+	clone := &OrderedMap[K, V]{entries: make(map[K]*orderedEntry[K, V], len(m.entries))}
+	for e := m.front; e != nil; e = e.next {
+		clone.Set(e.key, e.value)
+	}
+	return clone
+}
+
+// Clear removes all entries from m, leaving it empty.
+func (m *OrderedMap[K, V]) Clear() {
+	m.entries = make(map[K]*orderedEntry[K, V])
+	m.front, m.back = nil, nil
+}
+
+// Map returns the key/value pairs of m as a Map, losing their order.
+func (m *OrderedMap[K, V]) Map() Map[K, V] {
+	mapped := make(Map[K, V], len(m.entries))
+	for e := m.front; e != nil; e = e.next {
+		mapped[e.key] = e.value
+	}
+	return mapped
+}
+
+// EqualFunc reports whether m and m2 hold the same keys in the same order,
+// comparing their values using eq.
+func (m *OrderedMap[K, V]) EqualFunc(m2 *OrderedMap[K, V], eq func(V, V) bool) bool {
+	if m.Size() != m2.Size() {
+		return false
+	}
+	for e, e2 := m.front, m2.front; e != nil; e, e2 = e.next, e2.next {
+		if e.key != e2.key || !eq(e.value, e2.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Executes a provided function once per each key/value pair in the map, in insertion order.
+func (m *OrderedMap[K, V]) ForEach(callbackFn func(K, V)) {
+	if callbackFn == nil {
+		panic("callback function is nil")
+	}
+	for e := m.front; e != nil; e = e.next {
+		callbackFn(e.key, e.value)
+	}
+}
+
+// Filter returns a new map containing only the key-value pairs for which the callback function returns true,
+// in the same order.
+func (m *OrderedMap[K, V]) Filter(callbackFn func(K, V) bool) *OrderedMap[K, V] {
+	if callbackFn == nil {
+		panic("callback function is nil")
+	}
+	filtered := NewOrderedMap[K, V]()
+	for e := m.front; e != nil; e = e.next {
+		if callbackFn(e.key, e.value) {
+			filtered.Set(e.key, e.value)
+		}
+	}
+	return filtered
+}
+
+// Some returns true if at least one key-value pair in the map causes the callback function to return true.
+func (m *OrderedMap[K, V]) Some(callbackFn func(K, V) bool) bool {
+	if callbackFn == nil {
+		panic("callback function is nil")
+	}
+	for e := m.front; e != nil; e = e.next {
+		if callbackFn(e.key, e.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Every returns true if all key-value pairs in the map cause the callback function to return true.
+func (m *OrderedMap[K, V]) Every(callbackFn func(K, V) bool) bool {
+	if callbackFn == nil {
+		panic("callback function is nil")
+	}
+	for e := m.front; e != nil; e = e.next {
+		if !callbackFn(e.key, e.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Size returns the number of key-value pairs in the map.
+func (m *OrderedMap[K, V]) Size() int { return len(m.entries) }
+
+// IsEmpty returns true if the map is empty
+func (m *OrderedMap[K, V]) IsEmpty() bool { return m.Size() == 0 }
+
+// String formats the map like fmt formats a map, in insertion order: map[k1:v1 k2:v2].
+func (m *OrderedMap[K, V]) String() string {
+	var b strings.Builder
+	b.WriteString("map[")
+	for e := m.front; e != nil; e = e.next {
+		if e != m.front {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "%v:%v", e.key, e.value)
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The map is encoded as a JSON object whose members are in insertion order.
+// Keys are encoded like encoding/json encodes map keys: strings, integers and encoding.TextMarshaler are supported.
+// It has a value receiver so that an OrderedMap held by value in a struct field is encoded too.
+func (m OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for e := m.front; e != nil; e = e.next {
+		if e != m.front {
+			buf.WriteByte(',')
+		}
+		key, err := encodeKey(e.key)
+		if err != nil {
+			return nil, err
+		}
+		quoted, _ := json.Marshal(key)
+		buf.Write(quoted)
+		buf.WriteByte(':')
+		value, err := json.Marshal(e.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// The members of the JSON object are added in the order they appear; a repeated member keeps its first position.
+// Unmarshaling null leaves the map unchanged.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if token != json.Delim('{') {
+		return fmt.Errorf("maps: cannot unmarshal %v into an OrderedMap", token)
+	}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, err := decodeKey[K](token.(string))
+		if err != nil {
+			return err
+		}
+		var value V
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		m.Set(key, value)
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// encodeKey converts a map key to a JSON object member name, following the rules of encoding/json.
+func encodeKey(key any) (string, error) {
+	v := reflect.ValueOf(key)
+	if v.Kind() == reflect.String {
+		return v.String(), nil
+	}
+	if marshaler, ok := key.(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("maps: unsupported JSON key type %T", key)
+}
+
+// decodeKey converts a JSON object member name to a map key, following the rules of encoding/json.
+func decodeKey[K comparable](name string) (key K, err error) {
+	v := reflect.ValueOf(&key).Elem()
+	if v.Kind() == reflect.String {
+		v.SetString(name)
+		return key, nil
+	}
+	if unmarshaler, ok := any(&key).(encoding.TextUnmarshaler); ok {
+		err = unmarshaler.UnmarshalText([]byte(name))
+		return key, err
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(name, 10, v.Type().Bits())
+		if err != nil {
+			return key, fmt.Errorf("maps: invalid JSON key %q for type %T", name, key)
+		}
+		v.SetInt(n)
+		return key, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(name, 10, v.Type().Bits())
+		if err != nil {
+			return key, fmt.Errorf("maps: invalid JSON key %q for type %T", name, key)
+		}
+		v.SetUint(n)
+		return key, nil
+	}
+	return key, fmt.Errorf("maps: unsupported JSON key type %T", key)
+}
@@ -0,0 +1,167 @@
+package maps_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	. "github.com/cramanan/go-types/maps"
+)
+
+func orderedOf(keys ...string) *OrderedMap[string, int] {
+	m := NewOrderedMap[string, int]()
+	for i, key := range keys {
+		m.Set(key, i)
+	}
+	return m
+}
+
+func TestOrderedMap(t *testing.T) {
+	var zero OrderedMap[string, int]
+	zero.Set("a", 1)
+	if got, found := zero.Get("a"); !found || got != 1 {
+		t.Errorf("zero value Get(%q) got %d, %t, want %d, %t", "a", got, found, 1, true)
+	}
+
+	m := orderedOf("c", "a", "b")
+	m.Set("a", 10)
+	m.Delete("missing")
+
+	moved := orderedOf("a", "b", "c", "d")
+	moved.MoveToFront("c")
+	moved.MoveToBack("a")
+	moved.MoveToFront("c")
+
+	deleted := orderedOf("a", "b", "c")
+	deleted.Delete("b")
+	deleted.Delete("a")
+	deleted.Set("a", 4)
+
+	testCases := []struct {
+		desc      string
+		got, want any
+	}{
+		{"Keys", m.Keys(), []string{"c", "a", "b"}},
+		{"Values", m.Values(), []int{0, 10, 2}},
+		{"Size", m.Size(), 3},
+		{"Has", m.Has("b"), true},
+		{"MoveToFront/MoveToBack", moved.Keys(), []string{"c", "b", "d", "a"}},
+		{"MoveToFront missing", moved.MoveToFront("z"), false},
+		{"Delete", deleted.Keys(), []string{"c", "a"}},
+		{"String", m.String(), "map[c:0 a:10 b:2]"},
+		{"Map", m.Map(), Map[string, int]{"a": 10, "b": 2, "c": 0}},
+		{"Filter", m.Filter(func(k string, v int) bool { return v%2 == 0 }).Keys(), []string{"c", "a", "b"}},
+		{"Some", m.Some(func(k string, v int) bool { return v > 5 }), true},
+		{"Every", m.Every(func(k string, v int) bool { return v > 5 }), false},
+		{"EqualFunc", m.EqualFunc(m.Clone(), equal[int]), true},
+		{"EqualFunc order", orderedOf("a", "b").EqualFunc(orderedOf("b", "a"), func(int, int) bool { return true }), false},
+		{"IsEmpty", NewOrderedMap[int, int]().IsEmpty(), true},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !reflect.DeepEqual(tC.got, tC.want) {
+				t.Errorf("got %v, want %v", tC.got, tC.want)
+			}
+		})
+	}
+}
+
+func TestOrderedMapIteration(t *testing.T) {
+	m := orderedOf("a", "b", "c", "d")
+
+	var forward, backward []string
+	m.All()(func(k string, v int) bool {
+		forward = append(forward, k)
+		if k == "b" {
+			m.Delete(k)
+		}
+		return k != "c"
+	})
+	m.Backward()(func(k string, v int) bool {
+		backward = append(backward, k)
+		return true
+	})
+
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(forward, want) {
+		t.Errorf("All() got %v, want %v", forward, want)
+	}
+	if want := []string{"d", "c", "a"}; !reflect.DeepEqual(backward, want) {
+		t.Errorf("Backward() got %v, want %v", backward, want)
+	}
+	if k, v, ok := m.Front(); k != "a" || v != 0 || !ok {
+		t.Errorf("Front() got %v, %v, %v, want a, 0, true", k, v, ok)
+	}
+	if k, v, ok := m.Back(); k != "d" || v != 3 || !ok {
+		t.Errorf("Back() got %v, %v, %v, want d, 3, true", k, v, ok)
+	}
+	m.Clear()
+	if _, _, ok := m.Front(); ok {
+		t.Error("Front() of an empty map got ok")
+	}
+}
+
+func TestOrderedMapJSON(t *testing.T) {
+	m := orderedOf("zeta", "alpha", "mid")
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"zeta":0,"alpha":1,"mid":2}`; string(data) != want {
+		t.Errorf("Marshal got %s, want %s", data, want)
+	}
+
+	var decoded OrderedMap[string, int]
+	if err := json.Unmarshal([]byte(`{"b": 1, "a": 2, "b": 3}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := decoded.String(), "map[b:3 a:2]"; got != want {
+		t.Errorf("Unmarshal got %s, want %s", got, want)
+	}
+
+	nested := NewOrderedMap[int, *OrderedMap[string, int]]()
+	nested.Set(2, orderedOf("y", "x"))
+	nested.Set(1, NewOrderedMap[string, int]())
+	data, err = json.Marshal(nested)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"2":{"y":0,"x":1},"1":{}}`; string(data) != want {
+		t.Errorf("Marshal nested got %s, want %s", data, want)
+	}
+	var roundTrip OrderedMap[int, *OrderedMap[string, int]]
+	if err := json.Unmarshal(data, &roundTrip); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := roundTrip.Keys(), []int{2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Unmarshal nested keys got %v, want %v", got, want)
+	}
+
+	var invalid OrderedMap[int, int]
+	for _, data := range []string{`[1]`, `{"x": 1}`, `{"1": "x"}`} {
+		if err := json.Unmarshal([]byte(data), &invalid); err == nil {
+			t.Errorf("Unmarshal(%s) got no error", data)
+		}
+	}
+	if _, err := json.Marshal(NewOrderedMap[float64, int]().Clone()); err != nil {
+		t.Errorf("Marshal of an empty map got %v", err)
+	}
+	m2 := NewOrderedMap[float64, int]()
+	m2.Set(1.5, 1)
+	if _, err := json.Marshal(m2); err == nil {
+		t.Error("Marshal with float keys got no error")
+	}
+
+	var config struct {
+		Limits OrderedMap[string, int] `json:"limits"`
+	}
+	config.Limits.Set("b", 2)
+	config.Limits.Set("a", 1)
+	data, err = json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"limits":{"b":2,"a":1}}`; string(data) != want {
+		t.Errorf("Marshal of a struct field got %s, want %s", data, want)
+	}
+}
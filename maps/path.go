@@ -0,0 +1,461 @@
+package maps
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidPath is returned when a path expression cannot be parsed.
+	ErrInvalidPath = errors.New("maps: invalid path")
+
+	// ErrPathNotFound is returned when a segment of a path does not exist.
+	ErrPathNotFound = errors.New("maps: path not found")
+)
+
+// PathError records the segment of a path expression on which an operation failed.
+// Err is ErrInvalidPath, ErrPathNotFound or ErrTypeMismatch.
+type PathError struct {
+	Path    string
+	Segment string
+	Err     error
+}
+
+func (e *PathError) Error() string {
+	return fmt.Sprintf("%v at segment %q of path %q", e.Err, e.Segment, e.Path)
+}
+
+func (e *PathError) Unwrap() error { return e.Err }
+
+// pathSegment is a parsed segment of a path expression: a map key or a slice index.
+type pathSegment struct {
+	text     string // as written in the expression, for errors
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// parsePath parses a path expression such as `a.b[2].c`.
+// Keys are separated by dots and indices are written in brackets. A backslash escapes the next character of a key.
+// The key * and the index [*] are wildcards.
+func parsePath(path string) ([]pathSegment, error) {
+	invalid := func(segment string) error { return &PathError{path, segment, ErrInvalidPath} }
+	var segments []pathSegment
+	for i := 0; i < len(path); {
+		start := i
+		if path[i] == '[' {
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, invalid(path[i:])
+			}
+			text := path[i : i+end+1]
+			inner := text[1 : len(text)-1]
+			segment := pathSegment{text: text, isIndex: true, wildcard: inner == "*"}
+			if !segment.wildcard {
+				index, err := strconv.Atoi(inner)
+				if err != nil || index < 0 || strings.HasPrefix(inner, "+") {
+					return nil, invalid(text)
+				}
+				segment.index = index
+			}
+			segments = append(segments, segment)
+			i += end + 1
+		} else {
+			if len(segments) > 0 {
+				if path[i] != '.' {
+					return nil, invalid(path[i:])
+				}
+				i++
+				start = i
+			}
+			var key strings.Builder
+			escaped := false
+			for ; i < len(path) && (path[i] != '.' && path[i] != '[' || escaped); i++ {
+				if path[i] == '\\' && !escaped {
+					escaped = true
+					continue
+				}
+				escaped = false
+				key.WriteByte(path[i])
+			}
+			text := path[start:i]
+			if key.Len() == 0 || escaped {
+				return nil, invalid(text)
+			}
+			segments = append(segments, pathSegment{text: text, key: key.String(), wildcard: text == "*"})
+		}
+	}
+	if len(segments) == 0 {
+		return nil, invalid(path)
+	}
+	return segments, nil
+}
+
+// parseConcretePath parses a path expression that must not contain wildcards.
+func parseConcretePath(path string) ([]pathSegment, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, segment := range segments {
+		if segment.wildcard {
+			return nil, &PathError{path, segment.text, ErrInvalidPath}
+		}
+	}
+	return segments, nil
+}
+
+// GetPath returns the value at path in the nested maps and slices of m.
+//
+// Keys are separated by dots and slice indices are written in brackets: "servers[0].tls.port".
+// A backslash escapes a dot or a bracket in a key: `labels.app\.kubernetes\.io/name`.
+// Nested values can be maps with string keys and slices or arrays of any type.
+// The error is a *PathError naming the segment that does not exist or does not hold a map or a slice.
+func GetPath(m map[string]any, path string) (any, error) { return getPath(reflect.ValueOf(m), path) }
+
+// GetPathAs is like [GetPath] but returns the value as a T.
+// Numbers are converted to T if T is a numeric type and the conversion is exact,
+// so that a float64 decoded from JSON can be read as an int.
+// It returns a *PathError wrapping ErrTypeMismatch if the value cannot be converted.
+func GetPathAs[T any](m map[string]any, path string) (T, error) {
+	var zero T
+	value, err := GetPath(m, path)
+	if err != nil {
+		return zero, err
+	}
+	if typed, ok := convertTo[T](value); ok {
+		return typed, nil
+	}
+	segments, _ := parsePath(path)
+	return zero, &PathError{path, segments[len(segments)-1].text, fmt.Errorf("%w: %T is not %T", ErrTypeMismatch, value, zero)}
+}
+
+// HasPath reports whether a value exists at path in m. See [GetPath].
+func HasPath(m map[string]any, path string) bool {
+	_, err := GetPath(m, path)
+	return err == nil
+}
+
+// SetPath sets the value at path in m, creating the missing intermediate values:
+// a map[string]any before a key and a []any before an index. Slices too short for an index are extended with zero values,
+// by at most 65536 elements: an index further past the end is rejected with an error wrapping ErrInvalidPath.
+// Arrays are updated but never extended.
+// It returns a *PathError if a segment holds a value that is neither a map nor a slice,
+// or a map or a slice that cannot hold the new value.
+//
+// Example:
+//
+//	SetPath(doc, "spec.containers[0].image", "nginx:1.25")
+func SetPath(m map[string]any, path string, value any) error {
+	if m == nil {
+		return &PathError{path, path, fmt.Errorf("%w: nil map", ErrTypeMismatch)}
+	}
+	return setPath(reflect.ValueOf(m), path, value)
+}
+
+// DeletePath removes the value at path from m: a map entry is deleted and a slice element is removed,
+// shifting the next elements. It returns a *PathError wrapping ErrPathNotFound if there is no value at path.
+func DeletePath(m map[string]any, path string) error { return deletePath(reflect.ValueOf(m), path) }
+
+// Query returns the values matching a path expression with wildcards: "*" matches every key of a map,
+// in sorted order, and "[*]" every element of a slice. Branches where the path does not exist are skipped.
+// It returns an error only if the expression is invalid.
+//
+// Example:
+//
+//	ids, _ := Query(order, "items[*].id")
+func Query(m map[string]any, path string) ([]any, error) { return query(reflect.ValueOf(m), path) }
+
+// QueryAs is like [Query] but returns the matching values that are, or convert exactly to, a T.
+// See [GetPathAs].
+func QueryAs[T any](m map[string]any, path string) ([]T, error) {
+	values, err := Query(m, path)
+	if err != nil {
+		return nil, err
+	}
+	var typed []T
+	for _, value := range values {
+		if v, ok := convertTo[T](value); ok {
+			typed = append(typed, v)
+		}
+	}
+	return typed, nil
+}
+
+// GetPath returns the value at path in the nested maps and slices of the map. See [GetPath].
+func (m Map[K, V]) GetPath(path string) (any, error) { return getPath(reflect.ValueOf(m), path) }
+
+// HasPath reports whether a value exists at path in the map. See [GetPath].
+func (m Map[K, V]) HasPath(path string) bool {
+	_, err := m.GetPath(path)
+	return err == nil
+}
+
+// SetPath sets the value at path in the map, creating the missing intermediate values. See [SetPath].
+func (m Map[K, V]) SetPath(path string, value any) error {
+	if m == nil {
+		return &PathError{path, path, fmt.Errorf("%w: nil map", ErrTypeMismatch)}
+	}
+	return setPath(reflect.ValueOf(m), path, value)
+}
+
+// DeletePath removes the value at path from the map. See [DeletePath].
+func (m Map[K, V]) DeletePath(path string) error { return deletePath(reflect.ValueOf(m), path) }
+
+// Query returns the values matching a path expression with wildcards. See [Query].
+func (m Map[K, V]) Query(path string) ([]any, error) { return query(reflect.ValueOf(m), path) }
+
+func getPath(root reflect.Value, path string) (any, error) {
+	segments, err := parseConcretePath(path)
+	if err != nil {
+		return nil, err
+	}
+	v := root
+	for _, segment := range segments {
+		if v, err = child(v, segment); err != nil {
+			return nil, &PathError{path, segment.text, err}
+		}
+	}
+	return interfaceOf(v), nil
+}
+
+// child returns the value of segment in v, which must be a map with string keys or a slice or an array.
+func child(v reflect.Value, segment pathSegment) (reflect.Value, error) {
+	v = unwrap(v)
+	if segment.isIndex {
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return v, fmt.Errorf("%w: %s is not a slice", ErrTypeMismatch, typeName(v))
+		}
+		if segment.index >= v.Len() {
+			return v, ErrPathNotFound
+		}
+		return v.Index(segment.index), nil
+	}
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return v, fmt.Errorf("%w: %s is not a map with string keys", ErrTypeMismatch, typeName(v))
+	}
+	value := v.MapIndex(reflect.ValueOf(segment.key).Convert(v.Type().Key()))
+	if !value.IsValid() {
+		return v, ErrPathNotFound
+	}
+	return value, nil
+}
+
+func setPath(root reflect.Value, path string, value any) error {
+	segments, err := parseConcretePath(path)
+	if err != nil {
+		return err
+	}
+	_, err = setIn(root, segments, value, path)
+	return err
+}
+
+// maxPathGrowth is the maximum number of elements SetPath adds to a slice, so that a path from untrusted input
+// cannot allocate an arbitrarily large slice.
+const maxPathGrowth = 1 << 16
+
+// addressable returns a copy of v if v is an array that cannot be modified in place,
+// like an array held by an interface or a map value. The caller stores the copy back into the parent.
+func addressable(v reflect.Value) reflect.Value {
+	if v.Kind() != reflect.Array || v.CanSet() {
+		return v
+	}
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
+}
+
+// setIn sets value at segments in v, creating v if it is missing, and returns the updated v.
+func setIn(v reflect.Value, segments []pathSegment, value any, path string) (reflect.Value, error) {
+	v = unwrap(v)
+	segment := segments[0]
+	fail := func(err error) (reflect.Value, error) { return v, &PathError{path, segment.text, err} }
+	if !v.IsValid() || (v.Kind() == reflect.Interface && v.IsNil()) {
+		if segment.isIndex {
+			v = reflect.ValueOf([]any{})
+		} else {
+			v = reflect.ValueOf(map[string]any{})
+		}
+	}
+
+	v = addressable(v)
+
+	var current reflect.Value
+	var elem reflect.Type
+	switch {
+	case segment.isIndex && v.Kind() == reflect.Array:
+		if segment.index >= v.Len() {
+			return fail(fmt.Errorf("%w: index out of range of %s", ErrTypeMismatch, typeName(v)))
+		}
+		current, elem = v.Index(segment.index), v.Type().Elem()
+	case segment.isIndex && v.Kind() == reflect.Slice:
+		if segment.index >= v.Len() {
+			if segment.index-v.Len() >= maxPathGrowth {
+				return fail(fmt.Errorf("%w: index more than %d past the end of the slice", ErrInvalidPath, maxPathGrowth))
+			}
+			grown := reflect.MakeSlice(v.Type(), segment.index+1, segment.index+1)
+			reflect.Copy(grown, v)
+			v = grown
+		}
+		current, elem = v.Index(segment.index), v.Type().Elem()
+	case !segment.isIndex && v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+		if v.IsNil() {
+			return fail(fmt.Errorf("%w: nil map", ErrTypeMismatch))
+		}
+		current, elem = v.MapIndex(reflect.ValueOf(segment.key).Convert(v.Type().Key())), v.Type().Elem()
+	default:
+		_, err := child(v, segment)
+		return fail(err)
+	}
+
+	var updated reflect.Value
+	if len(segments) == 1 {
+		var ok bool
+		if updated, ok = valueOf(value, elem); !ok {
+			return fail(fmt.Errorf("%w: %T cannot be stored in %s", ErrTypeMismatch, value, typeName(v)))
+		}
+	} else {
+		var err error
+		if updated, err = setIn(current, segments[1:], value, path); err != nil {
+			return v, err
+		}
+		if !updated.Type().AssignableTo(elem) {
+			return fail(fmt.Errorf("%w: %s cannot be stored in %s", ErrTypeMismatch, typeName(updated), typeName(v)))
+		}
+	}
+
+	if segment.isIndex {
+		v.Index(segment.index).Set(updated)
+	} else {
+		v.SetMapIndex(reflect.ValueOf(segment.key).Convert(v.Type().Key()), updated)
+	}
+	return v, nil
+}
+
+func deletePath(root reflect.Value, path string) error {
+	segments, err := parseConcretePath(path)
+	if err != nil {
+		return err
+	}
+	_, err = deleteIn(root, segments, path)
+	return err
+}
+
+// deleteIn removes the value at segments from v and returns the updated v.
+func deleteIn(v reflect.Value, segments []pathSegment, path string) (reflect.Value, error) {
+	v = addressable(unwrap(v))
+	segment := segments[0]
+	current, err := child(v, segment)
+	if err != nil {
+		return v, &PathError{path, segment.text, err}
+	}
+
+	if len(segments) > 1 {
+		updated, err := deleteIn(current, segments[1:], path)
+		if err != nil {
+			return v, err
+		}
+		if segment.isIndex {
+			current.Set(updated)
+		} else {
+			v.SetMapIndex(reflect.ValueOf(segment.key).Convert(v.Type().Key()), updated)
+		}
+		return v, nil
+	}
+
+	switch {
+	case !segment.isIndex:
+		v.SetMapIndex(reflect.ValueOf(segment.key).Convert(v.Type().Key()), reflect.Value{})
+	case v.Kind() == reflect.Array:
+		return v, &PathError{path, segment.text, fmt.Errorf("%w: cannot remove from %s", ErrTypeMismatch, typeName(v))}
+	default:
+		reflect.Copy(v.Slice(segment.index, v.Len()), v.Slice(segment.index+1, v.Len()))
+		v.Index(v.Len() - 1).Set(reflect.Zero(v.Type().Elem()))
+		v = v.Slice(0, v.Len()-1)
+	}
+	return v, nil
+}
+
+func query(root reflect.Value, path string) ([]any, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	var values []any
+	var visit func(v reflect.Value, segments []pathSegment)
+	visit = func(v reflect.Value, segments []pathSegment) {
+		if len(segments) == 0 {
+			values = append(values, interfaceOf(v))
+			return
+		}
+		segment := segments[0]
+		if !segment.wildcard {
+			if next, err := child(v, segment); err == nil {
+				visit(next, segments[1:])
+			}
+			return
+		}
+		v = unwrap(v)
+		switch {
+		case segment.isIndex && (v.Kind() == reflect.Slice || v.Kind() == reflect.Array):
+			for i := 0; i < v.Len(); i++ {
+				visit(v.Index(i), segments[1:])
+			}
+		case !segment.isIndex && v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+			keys := v.MapKeys()
+			sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+			for _, key := range keys {
+				visit(v.MapIndex(key), segments[1:])
+			}
+		}
+	}
+	visit(root, segments)
+	return values, nil
+}
+
+// convertTo returns value as a T, converting numbers exactly.
+func convertTo[T any](value any) (T, bool) {
+	if typed, ok := value.(T); ok {
+		return typed, true
+	}
+	var zero T
+	target := reflect.TypeOf(&zero).Elem()
+	v := reflect.ValueOf(value)
+	if !v.IsValid() || !isNumber(v.Kind()) || !isNumber(target.Kind()) {
+		return zero, false
+	}
+	converted := v.Convert(target)
+	// A conversion between signed and unsigned types of the same width round-trips but flips the sign.
+	if converted.Convert(v.Type()).Interface() != value || isNegative(converted) != isNegative(v) {
+		return zero, false
+	}
+	return converted.Interface().(T), true
+}
+
+// isNegative reports whether the number v is less than zero.
+func isNegative(v reflect.Value) bool {
+	switch {
+	case v.Kind() >= reflect.Int && v.Kind() <= reflect.Int64:
+		return v.Int() < 0
+	case v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64:
+		return v.Float() < 0
+	}
+	return false
+}
+
+func isNumber(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
+
+// typeName returns the type of v for error messages.
+func typeName(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	return v.Type().String()
+}
@@ -0,0 +1,183 @@
+package maps_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	. "github.com/cramanan/go-types/maps"
+)
+
+func newDocument() Map[string, any] {
+	return Map[string, any]{
+		"name": "order",
+		"items": []any{
+			map[string]any{"id": 1.0, "tags": []string{"a", "b"}},
+			map[string]any{"id": 2.0},
+			map[string]any{"sku": "x"},
+		},
+		"meta":   Map[string, any]{"a.b": true, "count": 3.5},
+		"owners": map[string]any{"ann": map[string]any{"id": 7.0}, "bob": map[string]any{"id": 8.0}},
+	}
+}
+
+func TestGetPath(t *testing.T) {
+	doc := newDocument()
+	tag, _ := GetPath(doc, "items[0].tags[1]")
+	escaped, _ := doc.GetPath(`meta.a\.b`)
+	id, _ := GetPathAs[int](doc, "items[1].id")
+	_, inexact := GetPathAs[int](doc, "meta.count")
+	_, notFound := GetPath(doc, "items[2].id")
+	_, outOfRange := GetPath(doc, "items[3]")
+	_, notSlice := GetPath(doc, "name[0]")
+	_, invalid := GetPath(doc, "items[x]")
+	_, wildcard := GetPath(doc, "items[*].id")
+
+	testCases := []struct {
+		desc      string
+		got, want any
+	}{
+		{"nested", tag, "b"},
+		{"escaped dot", escaped, true},
+		{"GetPathAs converts numbers", id, 2},
+		{"GetPathAs inexact", errors.Is(inexact, ErrTypeMismatch), true},
+		{"GetPathAs negative to unsigned", getAsError[uint](-1), true},
+		{"GetPathAs negative float to unsigned", getAsError[uint8](-1.0), true},
+		{"GetPathAs unsigned overflow", getAsError[int](uint(1 << 63)), true},
+		{"GetPathAs unsigned", getAsError[uint16](7.0), false},
+		{"HasPath", HasPath(doc, "owners.ann.id"), true},
+		{"HasPath missing", doc.HasPath("owners.cid"), false},
+		{"not found", notFound.Error(), `maps: path not found at segment "id" of path "items[2].id"`},
+		{"out of range", errors.Is(outOfRange, ErrPathNotFound), true},
+		{"not a slice", errors.Is(notSlice, ErrTypeMismatch), true},
+		{"invalid", invalid.Error(), `maps: invalid path at segment "[x]" of path "items[x]"`},
+		{"wildcard outside Query", errors.Is(wildcard, ErrInvalidPath), true},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !reflect.DeepEqual(tC.got, tC.want) {
+				t.Errorf("got %v, want %v", tC.got, tC.want)
+			}
+		})
+	}
+
+	var pathErr *PathError
+	if !errors.As(notFound, &pathErr) || pathErr.Segment != "id" || pathErr.Path != "items[2].id" {
+		t.Errorf("GetPath error got %#v", notFound)
+	}
+}
+
+// getAsError reports whether GetPathAs[T] fails to read value with ErrTypeMismatch.
+func getAsError[T any](value any) bool {
+	_, err := GetPathAs[T](map[string]any{"x": value}, "x")
+	return errors.Is(err, ErrTypeMismatch)
+}
+
+func TestSetPath(t *testing.T) {
+	doc := map[string]any{"a": map[string]any{"b": 1}, "s": "x"}
+	if err := SetPath(doc, "a.c[2].d", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetPath(doc, "a.b", 2); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"a": map[string]any{"b": 2, "c": []any{nil, nil, map[string]any{"d": true}}},
+		"s": "x",
+	}
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("SetPath got %v, want %v", doc, want)
+	}
+
+	if err := SetPath(doc, "s.t", 1); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("SetPath through a string got error %v", err)
+	}
+	typed := map[string]any{"tags": []string{"a"}}
+	if err := SetPath(typed, "tags[1]", 2); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("SetPath of a wrong type got error %v", err)
+	}
+	if err := SetPath(typed, "tags[1]", "b"); err != nil || !reflect.DeepEqual(typed["tags"], []string{"a", "b"}) {
+		t.Errorf("SetPath in a typed slice got %v, %v", typed, err)
+	}
+
+	if err := SetPath(doc, "a.c[1000000000]", 1); !errors.Is(err, ErrInvalidPath) {
+		t.Errorf("SetPath far past the end of a slice got error %v", err)
+	}
+	if err := SetPath(doc, "a.c[5]", 1); err != nil || len(doc["a"].(map[string]any)["c"].([]any)) != 6 {
+		t.Errorf("SetPath past the end of a slice got %v, %v", doc, err)
+	}
+
+	arrays := map[string]any{"a": [3]int{1, 2, 3}, "b": [1]map[string]any{{"x": 1}}}
+	if err := SetPath(arrays, "a[1]", 5); err != nil || arrays["a"] != [3]int{1, 5, 3} {
+		t.Errorf("SetPath in an array got %v, %v", arrays, err)
+	}
+	if err := SetPath(arrays, "a[3]", 5); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("SetPath past the end of an array got error %v", err)
+	}
+	if err := SetPath(arrays, "b[0].y", 2); err != nil || !reflect.DeepEqual(arrays["b"], [1]map[string]any{{"x": 1, "y": 2}}) {
+		t.Errorf("SetPath under an array got %v, %v", arrays, err)
+	}
+
+	m := Map[string, any]{}
+	if err := m.SetPath("x[0]", "y"); err != nil || !reflect.DeepEqual(m, Map[string, any]{"x": []any{"y"}}) {
+		t.Errorf("Map.SetPath got %v, %v", m, err)
+	}
+}
+
+func TestDeletePath(t *testing.T) {
+	doc := newDocument()
+	if err := DeletePath(doc, "items[0]"); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.DeletePath("owners.ann"); err != nil {
+		t.Fatal(err)
+	}
+	ids, _ := doc.Query("items[*].id")
+	owners, _ := doc.Query("owners.*.id")
+	if !reflect.DeepEqual(ids, []any{2.0}) || !reflect.DeepEqual(owners, []any{8.0}) {
+		t.Errorf("DeletePath got %v", doc)
+	}
+	if err := DeletePath(doc, "owners.ann"); !errors.Is(err, ErrPathNotFound) {
+		t.Errorf("DeletePath twice got error %v", err)
+	}
+
+	arrays := map[string]any{"a": [2][]any{{1, 2}, {3}}, "b": [1]map[string]any{{"x": 1, "y": 2}}}
+	if err := DeletePath(arrays, "a[0][1]"); err != nil || !reflect.DeepEqual(arrays["a"], [2][]any{{1}, {3}}) {
+		t.Errorf("DeletePath under an array got %v, %v", arrays, err)
+	}
+	if err := DeletePath(arrays, "b[0].x"); err != nil || !reflect.DeepEqual(arrays["b"], [1]map[string]any{{"y": 2}}) {
+		t.Errorf("DeletePath under an array of maps got %v, %v", arrays, err)
+	}
+	if err := DeletePath(arrays, "a[0]"); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("DeletePath of an array element got error %v", err)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	doc := newDocument()
+	ids, _ := Query(doc, "items[*].id")
+	owners, _ := QueryAs[int](doc, "owners.*.id")
+	tags, _ := Query(doc, "items[*].tags[*]")
+	none, _ := Query(doc, "items[*].missing")
+	_, invalid := Query(doc, "items[")
+
+	testCases := []struct {
+		desc      string
+		got, want any
+	}{
+		{"slice wildcard", ids, []any{1.0, 2.0}},
+		{"map wildcard", owners, []int{7, 8}},
+		{"nested wildcards", tags, []any{"a", "b"}},
+		{"no match", none, []any(nil)},
+		{"invalid", errors.Is(invalid, ErrInvalidPath), true},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !reflect.DeepEqual(tC.got, tC.want) {
+				t.Errorf("got %v, want %v", tC.got, tC.want)
+			}
+		})
+	}
+}
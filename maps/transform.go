@@ -0,0 +1,185 @@
+package maps
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrKeyCollision is returned when a transformation maps two entries to the same key and no resolver is given.
+var ErrKeyCollision = errors.New("maps: key collision")
+
+// MapKeys returns a new map with every key replaced by fn(key, value).
+// When two entries get the same new key, resolve is called with that key and both values
+// and returns the value to keep; if resolve is nil, an error wrapping ErrKeyCollision is returned.
+// Since map iteration order is indeterminate, resolve should not depend on the order of its arguments.
+//
+// Example:
+//
+//	lower, err := MapKeys(headers, func(k, _ string) string { return strings.ToLower(k) },
+//		func(_, v1, v2 string) string { return v1 + ", " + v2 })
+func MapKeys[M ~map[K]V, K, L comparable, V any](m M, fn func(K, V) L, resolve func(key L, v1, v2 V) V) (map[L]V, error) {
+	if fn == nil {
+		panic("callback function is nil")
+	}
+	return MapEntries(m, func(key K, value V) (L, V) { return fn(key, value), value }, resolve)
+}
+
+// MapEntries returns a new map with every key/value pair replaced by fn(key, value).
+// Colliding keys are handled like in [MapKeys].
+func MapEntries[M ~map[K]V, K, L comparable, V, W any](m M, fn func(K, V) (L, W), resolve func(key L, w1, w2 W) W) (map[L]W, error) {
+	if fn == nil {
+		panic("callback function is nil")
+	}
+	mapped := make(map[L]W, len(m))
+	for key, value := range m {
+		newKey, newValue := fn(key, value)
+		if existing, found := mapped[newKey]; found {
+			if resolve == nil {
+				return nil, fmt.Errorf("%w: %v", ErrKeyCollision, newKey)
+			}
+			newValue = resolve(newKey, existing, newValue)
+		}
+		mapped[newKey] = newValue
+	}
+	return mapped, nil
+}
+
+// Invert returns a new map from the values of m to their keys.
+// It returns an error wrapping ErrKeyCollision if two keys have the same value; see [InvertMulti].
+func Invert[M ~map[K]V, K, V comparable](m M) (map[V]K, error) {
+	inverted := make(map[V]K, len(m))
+	for key, value := range m {
+		if _, found := inverted[value]; found {
+			return nil, fmt.Errorf("%w: %v", ErrKeyCollision, value)
+		}
+		inverted[value] = key
+	}
+	return inverted, nil
+}
+
+// InvertMulti returns a new map from the values of m to all the keys that have them.
+// The keys of each value will be in an indeterminate order.
+func InvertMulti[M ~map[K]V, K, V comparable](m M) map[V][]K {
+	inverted := make(map[V][]K)
+	for key, value := range m {
+		inverted[value] = append(inverted[value], key)
+	}
+	return inverted
+}
+
+// Pick returns a new map holding only the given keys of m. Keys not in m are ignored.
+func Pick[M ~map[K]V, K comparable, V any](m M, keys ...K) M {
+	picked := make(M, len(keys))
+	for _, key := range keys {
+		if value, found := m[key]; found {
+			picked[key] = value
+		}
+	}
+	return picked
+}
+
+// Omit returns a new map holding every entry of m except the given keys.
+func Omit[M ~map[K]V, K comparable, V any](m M, keys ...K) M {
+	omitted := Clone(m)
+	if omitted == nil {
+		return make(M)
+	}
+	for _, key := range keys {
+		delete(omitted, key)
+	}
+	return omitted
+}
+
+// Partition splits m into two new maps: the entries for which the callback function returns true, and the others.
+func Partition[M ~map[K]V, K comparable, V any](m M, callbackFn func(K, V) bool) (matched, rest M) {
+	if callbackFn == nil {
+		panic("callback function is nil")
+	}
+	matched, rest = make(M), make(M)
+	for key, value := range m {
+		if callbackFn(key, value) {
+			matched[key] = value
+		} else {
+			rest[key] = value
+		}
+	}
+	return matched, rest
+}
+
+// FilterKeys returns a new map containing only the entries whose key makes the callback function return true.
+func FilterKeys[M ~map[K]V, K comparable, V any](m M, callbackFn func(K) bool) M {
+	if callbackFn == nil {
+		panic("callback function is nil")
+	}
+	return Filter(m, func(key K, _ V) bool { return callbackFn(key) })
+}
+
+// FilterValues returns a new map containing only the entries whose value makes the callback function return true.
+func FilterValues[M ~map[K]V, K comparable, V any](m M, callbackFn func(V) bool) M {
+	if callbackFn == nil {
+		panic("callback function is nil")
+	}
+	return Filter(m, func(_ K, value V) bool { return callbackFn(value) })
+}
+
+// FindKey returns a key whose entry makes the callback function return true.
+// If several entries match, which one is returned is indeterminate. found is false if none matches.
+func FindKey[M ~map[K]V, K comparable, V any](m M, callbackFn func(K, V) bool) (key K, found bool) {
+	if callbackFn == nil {
+		panic("callback function is nil")
+	}
+	for k, value := range m {
+		if callbackFn(k, value) {
+			return k, true
+		}
+	}
+	return key, false
+}
+
+// Count returns the number of entries for which the callback function returns true.
+func Count[M ~map[K]V, K comparable, V any](m M, callbackFn func(K, V) bool) (count int) {
+	if callbackFn == nil {
+		panic("callback function is nil")
+	}
+	for key, value := range m {
+		if callbackFn(key, value) {
+			count++
+		}
+	}
+	return count
+}
+
+// MapKeys returns a new map with every key replaced by fn(key, value). See [MapKeys].
+func (m Map[K, V]) MapKeys(fn func(K, V) K, resolve func(key K, v1, v2 V) V) (Map[K, V], error) {
+	return MapKeys(m, fn, resolve)
+}
+
+// MapEntries returns a new map with every key/value pair replaced by fn(key, value). See [MapEntries].
+func (m Map[K, V]) MapEntries(fn func(K, V) (K, V), resolve func(key K, v1, v2 V) V) (Map[K, V], error) {
+	return MapEntries(m, fn, resolve)
+}
+
+// Pick returns a new map holding only the given keys. See [Pick].
+func (m Map[K, V]) Pick(keys ...K) Map[K, V] { return Pick(m, keys...) }
+
+// Omit returns a new map holding every entry except the given keys. See [Omit].
+func (m Map[K, V]) Omit(keys ...K) Map[K, V] { return Omit(m, keys...) }
+
+// Partition splits the map into the entries for which the callback function returns true, and the others.
+func (m Map[K, V]) Partition(callbackFn func(K, V) bool) (matched, rest Map[K, V]) {
+	return Partition(m, callbackFn)
+}
+
+// FilterKeys returns a new map containing only the entries whose key makes the callback function return true.
+func (m Map[K, V]) FilterKeys(callbackFn func(K) bool) Map[K, V] { return FilterKeys(m, callbackFn) }
+
+// FilterValues returns a new map containing only the entries whose value makes the callback function return true.
+func (m Map[K, V]) FilterValues(callbackFn func(V) bool) Map[K, V] {
+	return FilterValues(m, callbackFn)
+}
+
+// FindKey returns a key whose entry makes the callback function return true. See [FindKey].
+func (m Map[K, V]) FindKey(callbackFn func(K, V) bool) (K, bool) { return FindKey(m, callbackFn) }
+
+// Count returns the number of entries for which the callback function returns true.
+func (m Map[K, V]) Count(callbackFn func(K, V) bool) int { return Count(m, callbackFn) }
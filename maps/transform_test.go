@@ -0,0 +1,66 @@
+package maps_test
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	. "github.com/cramanan/go-types/maps"
+)
+
+func TestTransformations(t *testing.T) {
+	m := Map[string, int]{"a": 1, "B": 2, "b": 3, "c": 2}
+	lower := func(k string, _ int) string { return strings.ToLower(k) }
+	sum := func(_ string, v1, v2 int) int { return v1 + v2 }
+
+	lowered, err := m.MapKeys(lower, sum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, collision := MapKeys(m, lower, nil)
+	entries, _ := MapEntries(m, func(k string, v int) (int, string) { return v * 10, k }, func(_ int, a, b string) string {
+		if a < b {
+			return a
+		}
+		return b
+	})
+	_, invertErr := Invert(m)
+	inverted, _ := Invert(m.Omit("c"))
+	multi := InvertMulti(m)
+	sort.Strings(multi[2])
+	even, odd := m.Partition(func(_ string, v int) bool { return v%2 == 0 })
+	found, ok := m.FindKey(func(k string, v int) bool { return v == 3 })
+	_, notFound := m.FindKey(func(k string, v int) bool { return v > 3 })
+
+	testCases := []struct {
+		desc      string
+		got, want any
+	}{
+		{"MapKeys", lowered, Map[string, int]{"a": 1, "b": 5, "c": 2}},
+		{"MapKeys collision", errors.Is(collision, ErrKeyCollision), true},
+		{"MapEntries", entries, map[int]string{10: "a", 20: "B", 30: "b"}},
+		{"Invert collision", errors.Is(invertErr, ErrKeyCollision), true},
+		{"Invert", inverted, map[int]string{1: "a", 2: "B", 3: "b"}},
+		{"InvertMulti", multi, map[int][]string{1: {"a"}, 2: {"B", "c"}, 3: {"b"}}},
+		{"Pick", m.Pick("a", "z"), Map[string, int]{"a": 1}},
+		{"Omit", m.Omit("a", "B", "z"), Map[string, int]{"b": 3, "c": 2}},
+		{"Omit nil", Omit(map[string]int(nil), "a"), map[string]int{}},
+		{"Partition matched", even, Map[string, int]{"B": 2, "c": 2}},
+		{"Partition rest", odd, Map[string, int]{"a": 1, "b": 3}},
+		{"FilterKeys", m.FilterKeys(func(k string) bool { return k > "a" }), Map[string, int]{"b": 3, "c": 2}},
+		{"FilterValues", m.FilterValues(func(v int) bool { return v < 2 }), Map[string, int]{"a": 1}},
+		{"FindKey", []any{found, ok}, []any{"b", true}},
+		{"FindKey none", notFound, false},
+		{"Count", m.Count(func(_ string, v int) bool { return v == 2 }), 2},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !reflect.DeepEqual(tC.got, tC.want) {
+				t.Errorf("got %v, want %v", tC.got, tC.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,528 @@
+package maps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cramanan/go-types/functions"
+	"golang.org/x/exp/constraints"
+)
+
+// TreeMapFunc is a map whose keys are kept sorted by a comparison function.
+// It is an AVL tree augmented with subtree sizes: Get, Set, Delete, Floor, Ceiling,
+// Rank and Select run in O(log n) and iterating runs in O(n).
+//
+// Use [NewTreeMapFunc] to create one, or [TreeMap] for ordered keys: the zero value has no comparison function,
+// so Set panics on it. A TreeMapFunc is not safe for concurrent use.
+type TreeMapFunc[K, V any] struct {
+	root *treeNode[K, V]
+	cmp  functions.ComparisonFunc[K]
+}
+
+// TreeMap is a [TreeMapFunc] whose keys are sorted in ascending order.
+// For floating-point keys, NaN is considered less than any other value and equal to itself.
+//
+// The zero value is an empty map ready to use.
+type TreeMap[K constraints.Ordered, V any] struct {
+	TreeMapFunc[K, V]
+}
+
+// treeNode is a node of the tree with the height and the size of its subtree.
+type treeNode[K, V any] struct {
+	key         K
+	value       V
+	left, right *treeNode[K, V]
+	height      int
+	size        int
+}
+
+// NewTreeMap allocates and initializes an empty TreeMap.
+func NewTreeMap[K constraints.Ordered, V any]() *TreeMap[K, V] {
+	return &TreeMap[K, V]{TreeMapFunc[K, V]{cmp: functions.Compare[K]}}
+}
+
+// NewTreeMapFunc allocates and initializes an empty TreeMapFunc sorting its keys with cmp.
+// Keys for which cmp returns 0 are the same key.
+func NewTreeMapFunc[K, V any](cmp functions.ComparisonFunc[K]) *TreeMapFunc[K, V] {
+	if cmp == nil {
+		panic("callback function is nil")
+	}
+	return &TreeMapFunc[K, V]{cmp: cmp}
+}
+
+// Get retrieves the value for a given key from the map.
+// If the key is not present in the map, the second found value is false.
+func (m *TreeMapFunc[K, V]) Get(key K) (value V, found bool) {
+	for n := m.root; n != nil; {
+		switch c := m.cmp(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+	return value, false
+}
+
+// Has reports whether key is present in the map.
+func (m *TreeMapFunc[K, V]) Has(key K) bool {
+	_, found := m.Get(key)
+	return found
+}
+
+// Set sets the value for a given key in the map.
+// If the key already exists, the old value is replaced.
+// The new value is returned.
+func (m *TreeMapFunc[K, V]) Set(key K, value V) V {
+	if m.cmp == nil {
+		panic("maps: TreeMapFunc has no comparison function, use NewTreeMapFunc")
+	}
+	m.root = m.insert(m.root, key, value)
+	return value
+}
+
+// Delete removes key from the map and reports whether it was present.
+func (m *TreeMapFunc[K, V]) Delete(key K) (found bool) {
+	m.root, found = m.remove(m.root, key)
+	return found
+}
+
+// Min returns the smallest key of the map and its value. ok is false if the map is empty.
+func (m *TreeMapFunc[K, V]) Min() (key K, value V, ok bool) {
+	if m.root == nil {
+		return key, value, false
+	}
+	n := m.root
+	for n.left != nil {
+		n = n.left
+	}
+	return n.key, n.value, true
+}
+
+// Max returns the largest key of the map and its value. ok is false if the map is empty.
+func (m *TreeMapFunc[K, V]) Max() (key K, value V, ok bool) {
+	if m.root == nil {
+		return key, value, false
+	}
+	n := m.root
+	for n.right != nil {
+		n = n.right
+	}
+	return n.key, n.value, true
+}
+
+// Floor returns the largest key less than or equal to key, and its value.
+// ok is false if there is no such key.
+func (m *TreeMapFunc[K, V]) Floor(key K) (floor K, value V, ok bool) {
+	var found *treeNode[K, V]
+	for n := m.root; n != nil; {
+		switch c := m.cmp(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			found, n = n, n.right
+		default:
+			return n.key, n.value, true
+		}
+	}
+	if found == nil {
+		return floor, value, false
+	}
+	return found.key, found.value, true
+}
+
+// Ceiling returns the smallest key greater than or equal to key, and its value.
+// ok is false if there is no such key.
+func (m *TreeMapFunc[K, V]) Ceiling(key K) (ceiling K, value V, ok bool) {
+	var found *treeNode[K, V]
+	for n := m.root; n != nil; {
+		switch c := m.cmp(key, n.key); {
+		case c < 0:
+			found, n = n, n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.key, n.value, true
+		}
+	}
+	if found == nil {
+		return ceiling, value, false
+	}
+	return found.key, found.value, true
+}
+
+// Rank returns the number of keys of the map strictly less than key.
+// If key is present, it is its index in the sorted keys.
+func (m *TreeMapFunc[K, V]) Rank(key K) int {
+	rank := 0
+	for n := m.root; n != nil; {
+		switch c := m.cmp(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			rank += treeSize(n.left) + 1
+			n = n.right
+		default:
+			return rank + treeSize(n.left)
+		}
+	}
+	return rank
+}
+
+// Select returns the key at index i of the sorted keys, and its value, so that Select(Rank(key)) returns key.
+// ok is false if i is out of range.
+func (m *TreeMapFunc[K, V]) Select(i int) (key K, value V, ok bool) {
+	if i < 0 || i >= m.Size() {
+		return key, value, false
+	}
+	n := m.root
+	for {
+		left := treeSize(n.left)
+		switch {
+		case i < left:
+			n = n.left
+		case i > left:
+			i -= left + 1
+			n = n.right
+		default:
+			return n.key, n.value, true
+		}
+	}
+}
+
+// All returns an iterator over the key/value pairs of the map, in ascending key order.
+// The map must not be modified during the iteration.
+func (m *TreeMapFunc[K, V]) All() functions.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var stack []*treeNode[K, V]
+		for n := m.root; n != nil || len(stack) > 0; n = n.right {
+			for ; n != nil; n = n.left {
+				stack = append(stack, n)
+			}
+			n = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(n.key, n.value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the key/value pairs of the map, in descending key order.
+// The map must not be modified during the iteration.
+func (m *TreeMapFunc[K, V]) Backward() functions.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var stack []*treeNode[K, V]
+		for n := m.root; n != nil || len(stack) > 0; n = n.left {
+			for ; n != nil; n = n.right {
+				stack = append(stack, n)
+			}
+			n = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(n.key, n.value) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the key/value pairs whose key is in the half-open range [lo, hi),
+// in ascending key order. The map must not be modified during the iteration.
+//
+// Example:
+//
+//	m.Range(start, end)(func(at time.Time, event Event) bool {
+//		fmt.Println(at, event)
+//		return true
+//	})
+func (m *TreeMapFunc[K, V]) Range(lo, hi K) functions.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var stack []*treeNode[K, V]
+		// Only the nodes not less than lo are stacked.
+		for n := m.root; n != nil; {
+			if m.cmp(n.key, lo) < 0 {
+				n = n.right
+			} else {
+				stack = append(stack, n)
+				n = n.left
+			}
+		}
+		for len(stack) > 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if m.cmp(n.key, hi) >= 0 || !yield(n.key, n.value) {
+				return
+			}
+			for n = n.right; n != nil; n = n.left {
+				stack = append(stack, n)
+			}
+		}
+	}
+}
+
+// Keys returns the keys of the map in ascending order.
+func (m *TreeMapFunc[K, V]) Keys() []K {
+	keys := make([]K, 0, m.Size())
+	m.All()(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values returns the values of the map in ascending key order.
+func (m *TreeMapFunc[K, V]) Values() []V {
+	values := make([]V, 0, m.Size())
+	m.All()(func(_ K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Clone returns a copy of m. This is a shallow clone:
+// the new keys and values are set using ordinary assignment.
+func (m *TreeMapFunc[K, V]) Clone() *TreeMapFunc[K, V] {
+	return &TreeMapFunc[K, V]{root: cloneTree(m.root), cmp: m.cmp}
+}
+
+// Clear removes all entries from m, leaving it empty.
+func (m *TreeMapFunc[K, V]) Clear() { m.root = nil }
+
+// Executes a provided function once per each key/value pair in the map, in ascending key order.
+func (m *TreeMapFunc[K, V]) ForEach(callbackFn func(K, V)) {
+	if callbackFn == nil {
+		panic("callback function is nil")
+	}
+	m.All()(func(key K, value V) bool {
+		callbackFn(key, value)
+		return true
+	})
+}
+
+// Filter returns a new map containing only the key-value pairs for which the callback function returns true.
+func (m *TreeMapFunc[K, V]) Filter(callbackFn func(K, V) bool) *TreeMapFunc[K, V] {
+	if callbackFn == nil {
+		panic("callback function is nil")
+	}
+	filtered := &TreeMapFunc[K, V]{cmp: m.cmp}
+	m.All()(func(key K, value V) bool {
+		if callbackFn(key, value) {
+			filtered.Set(key, value)
+		}
+		return true
+	})
+	return filtered
+}
+
+// Some returns true if at least one key-value pair in the map causes the callback function to return true.
+func (m *TreeMapFunc[K, V]) Some(callbackFn func(K, V) bool) (some bool) {
+	if callbackFn == nil {
+		panic("callback function is nil")
+	}
+	m.All()(func(key K, value V) bool {
+		some = callbackFn(key, value)
+		return !some
+	})
+	return some
+}
+
+// Every returns true if all key-value pairs in the map cause the callback function to return true.
+func (m *TreeMapFunc[K, V]) Every(callbackFn func(K, V) bool) bool {
+	if callbackFn == nil {
+		panic("callback function is nil")
+	}
+	return !m.Some(func(key K, value V) bool { return !callbackFn(key, value) })
+}
+
+// Size returns the number of key-value pairs in the map.
+func (m *TreeMapFunc[K, V]) Size() int { return treeSize(m.root) }
+
+// IsEmpty returns true if the map is empty
+func (m *TreeMapFunc[K, V]) IsEmpty() bool { return m.root == nil }
+
+// String formats the map like fmt formats a map, in ascending key order: map[k1:v1 k2:v2].
+func (m *TreeMapFunc[K, V]) String() string {
+	var b strings.Builder
+	b.WriteString("map[")
+	m.All()(func(key K, value V) bool {
+		if b.Len() > len("map[") {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "%v:%v", key, value)
+		return true
+	})
+	b.WriteByte(']')
+	return b.String()
+}
+
+// Set sets the value for a given key in the map. See [TreeMapFunc.Set].
+func (m *TreeMap[K, V]) Set(key K, value V) V {
+	// Only insertions compare keys of a zero TreeMap: an empty tree is never searched.
+	if m.cmp == nil {
+		m.cmp = functions.Compare[K]
+	}
+	return m.TreeMapFunc.Set(key, value)
+}
+
+// Clone returns a copy of m. See [TreeMapFunc.Clone].
+func (m *TreeMap[K, V]) Clone() *TreeMap[K, V] {
+	return &TreeMap[K, V]{*m.TreeMapFunc.Clone()}
+}
+
+// Filter returns a new map containing only the key-value pairs for which the callback function returns true.
+func (m *TreeMap[K, V]) Filter(callbackFn func(K, V) bool) *TreeMap[K, V] {
+	return &TreeMap[K, V]{*m.TreeMapFunc.Filter(callbackFn)}
+}
+
+// Map returns the key/value pairs of m as a Map, losing their order.
+func (m *TreeMap[K, V]) Map() Map[K, V] {
+	mapped := make(Map[K, V], m.Size())
+	m.All()(func(key K, value V) bool {
+		mapped[key] = value
+		return true
+	})
+	return mapped
+}
+
+// ReduceSeq applies a reduction function to each key/value pair yielded by seq, in order, and returns a single value.
+// It is the ordered counterpart of [Reduce] for [TreeMapFunc.All], [OrderedMap.All] and the like.
+//
+// Example:
+//
+//	total := ReduceSeq(m.Range(from, to), func(sum float64, at time.Time, price float64) float64 { return sum + price }, 0)
+func ReduceSeq[K, V, I any](seq functions.Seq2[K, V], callbackFn func(I, K, V) I, initialValue I) I {
+	if callbackFn == nil {
+		panic("callback function is nil")
+	}
+	result := initialValue
+	seq(func(key K, value V) bool {
+		result = callbackFn(result, key, value)
+		return true
+	})
+	return result
+}
+
+// insert adds or replaces key in the subtree n and returns its new root.
+func (m *TreeMapFunc[K, V]) insert(n *treeNode[K, V], key K, value V) *treeNode[K, V] {
+	if n == nil {
+		return &treeNode[K, V]{key: key, value: value, height: 1, size: 1}
+	}
+	switch c := m.cmp(key, n.key); {
+	case c < 0:
+		n.left = m.insert(n.left, key, value)
+	case c > 0:
+		n.right = m.insert(n.right, key, value)
+	default:
+		n.value = value
+		return n
+	}
+	return rebalance(n)
+}
+
+// remove deletes key from the subtree n and returns its new root.
+func (m *TreeMapFunc[K, V]) remove(n *treeNode[K, V], key K) (*treeNode[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	var found bool
+	switch c := m.cmp(key, n.key); {
+	case c < 0:
+		n.left, found = m.remove(n.left, key)
+	case c > 0:
+		n.right, found = m.remove(n.right, key)
+	default:
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		// Replace the node by its successor, the minimum of its right subtree.
+		var successor *treeNode[K, V]
+		n.right, successor = removeMin(n.right)
+		successor.left, successor.right = n.left, n.right
+		n, found = successor, true
+	}
+	return rebalance(n), found
+}
+
+// removeMin detaches the minimum of the subtree n and returns the new root and the detached node.
+func removeMin[K, V any](n *treeNode[K, V]) (*treeNode[K, V], *treeNode[K, V]) {
+	if n.left == nil {
+		return n.right, n
+	}
+	var first *treeNode[K, V]
+	n.left, first = removeMin(n.left)
+	return rebalance(n), first
+}
+
+// rebalance restores the AVL invariant at n, whose subtrees are balanced, and returns the new root.
+func rebalance[K, V any](n *treeNode[K, V]) *treeNode[K, V] {
+	switch balance := treeHeight(n.left) - treeHeight(n.right); {
+	case balance > 1:
+		if treeHeight(n.left.left) < treeHeight(n.left.right) {
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+	case balance < -1:
+		if treeHeight(n.right.right) < treeHeight(n.right.left) {
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	}
+	n.update()
+	return n
+}
+
+// rotateLeft makes the right child of n the root of the subtree.
+func rotateLeft[K, V any](n *treeNode[K, V]) *treeNode[K, V] {
+	r := n.right
+	n.right, r.left = r.left, n
+	n.update()
+	r.update()
+	return r
+}
+
+// rotateRight makes the left child of n the root of the subtree.
+func rotateRight[K, V any](n *treeNode[K, V]) *treeNode[K, V] {
+	l := n.left
+	n.left, l.right = l.right, n
+	n.update()
+	l.update()
+	return l
+}
+
+// update recomputes the height and the size of n from its children.
+func (n *treeNode[K, V]) update() {
+	n.height = treeHeight(n.left) + 1
+	if h := treeHeight(n.right) + 1; h > n.height {
+		n.height = h
+	}
+	n.size = treeSize(n.left) + treeSize(n.right) + 1
+}
+
+func treeHeight[K, V any](n *treeNode[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func treeSize[K, V any](n *treeNode[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func cloneTree[K, V any](n *treeNode[K, V]) *treeNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	clone := *n
+	clone.left, clone.right = cloneTree(n.left), cloneTree(n.right)
+	return &clone
+}
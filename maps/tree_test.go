@@ -0,0 +1,152 @@
+package maps_test
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	. "github.com/cramanan/go-types/maps"
+)
+
+func TestTreeMap(t *testing.T) {
+	m := NewTreeMap[int, string]()
+	for _, k := range []int{50, 20, 80, 10, 30, 70, 90} {
+		m.Set(k, strings.Repeat("x", k/10))
+	}
+	m.Set(30, "thirty")
+	m.Delete(80)
+
+	type entry struct {
+		Key   int
+		Value string
+		OK    bool
+	}
+	get := func(k int, v string, ok bool) entry { return entry{k, v, ok} }
+
+	var ranged, backward []int
+	m.Range(15, 70)(func(k int, _ string) bool {
+		ranged = append(ranged, k)
+		return true
+	})
+	m.Backward()(func(k int, _ string) bool {
+		backward = append(backward, k)
+		return len(backward) < 3
+	})
+
+	testCases := []struct {
+		desc      string
+		got, want any
+	}{
+		{"Keys", m.Keys(), []int{10, 20, 30, 50, 70, 90}},
+		{"Size", m.Size(), 6},
+		{"Set replaces", m.Values()[2], "thirty"},
+		{"Has", m.Has(80), false},
+		{"Min", get(m.Min()), get(10, "x", true)},
+		{"Max", get(m.Max()), get(90, "xxxxxxxxx", true)},
+		{"Floor", get(m.Floor(65)), get(50, "xxxxx", true)},
+		{"Floor exact", get(m.Floor(70)), get(70, "xxxxxxx", true)},
+		{"Floor none", get(m.Floor(5)), get(0, "", false)},
+		{"Ceiling", get(m.Ceiling(65)), get(70, "xxxxxxx", true)},
+		{"Ceiling none", get(m.Ceiling(95)), get(0, "", false)},
+		{"Rank", m.Rank(50), 3},
+		{"Rank missing", m.Rank(55), 4},
+		{"Select", get(m.Select(1)), get(20, "xx", true)},
+		{"Select out of range", get(m.Select(6)), get(0, "", false)},
+		{"Range", ranged, []int{20, 30, 50}},
+		{"Backward", backward, []int{90, 70, 50}},
+		{"Filter", m.Filter(func(k int, _ string) bool { return k%20 == 10 }).Keys(), []int{10, 30, 50, 70, 90}},
+		{"Some", m.Some(func(k int, _ string) bool { return k > 80 }), true},
+		{"Every", m.Every(func(k int, _ string) bool { return k > 10 }), false},
+		{"ReduceSeq", ReduceSeq(m.All(), func(sum, k int, _ string) int { return sum + k }, 0), 270},
+		{"String", NewTreeMap[string, int]().String(), "map[]"},
+		{"Map", m.Filter(func(k int, _ string) bool { return k < 30 }).Map(), Map[int, string]{10: "x", 20: "xx"}},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !reflect.DeepEqual(tC.got, tC.want) {
+				t.Errorf("got %v, want %v", tC.got, tC.want)
+			}
+		})
+	}
+}
+
+func TestTreeMapFunc(t *testing.T) {
+	m := NewTreeMapFunc[string, int](func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) })
+	m.Set("b", 1)
+	m.Set("A", 2)
+	m.Set("a", 3)
+	m.Set("C", 4)
+
+	clone := m.Clone()
+	clone.Delete("B")
+
+	if got, want := m.String(), "map[A:3 b:1 C:4]"; got != want {
+		t.Errorf("String() got %s, want %s", got, want)
+	}
+	if got, want := clone.Keys(), []string{"A", "C"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Clone().Delete() got %v, want %v", got, want)
+	}
+	if m.Size() != 3 {
+		t.Errorf("Size() after deleting from a clone got %d, want 3", m.Size())
+	}
+	panics(t, func() { NewTreeMapFunc[int, int](nil) })
+	panics(t, func() { new(TreeMapFunc[int, int]).Set(1, 1) })
+
+	var zero TreeMap[string, int]
+	if _, _, ok := zero.Floor("a"); ok || zero.Rank("a") != 0 || zero.Delete("a") {
+		t.Error("zero TreeMap is not empty")
+	}
+	for _, k := range []string{"b", "c", "a"} {
+		zero.Set(k, len(k))
+	}
+	if got, want := zero.Keys(), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("zero TreeMap Keys() got %v, want %v", got, want)
+	}
+}
+
+// TestTreeMapRandom checks the tree against a sorted slice through random insertions and deletions.
+func TestTreeMapRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := NewTreeMap[int, int]()
+	reference := map[int]int{}
+	for i := 0; i < 5000; i++ {
+		k := r.Intn(500)
+		if r.Intn(3) == 0 {
+			_, want := reference[k]
+			if got := m.Delete(k); got != want {
+				t.Fatalf("Delete(%d) got %t, want %t", k, got, want)
+			}
+			delete(reference, k)
+		} else {
+			m.Set(k, i)
+			reference[k] = i
+		}
+	}
+
+	keys := Keys(reference)
+	sort.Ints(keys)
+	if got := m.Keys(); !reflect.DeepEqual(got, keys) {
+		t.Fatalf("Keys() got %v, want %v", got, keys)
+	}
+	for i, k := range keys {
+		if got := m.Rank(k); got != i {
+			t.Errorf("Rank(%d) got %d, want %d", k, got, i)
+		}
+		if got, v, _ := m.Select(i); got != k || v != reference[k] {
+			t.Errorf("Select(%d) got %d, %d, want %d, %d", i, got, v, k, reference[k])
+		}
+	}
+}
+
+func panics(t *testing.T, f func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+	f()
+}
@@ -0,0 +1,255 @@
+package slices
+
+import "github.com/cramanan/go-types/functions"
+
+// Permutations returns a lazy iterator over every ordered arrangement of k elements of s.
+// Arrangements are produced in lexicographic order of the element positions,
+// so a sorted input yields sorted permutations.
+// Elements are treated as unique based on their position, not their value.
+//
+// Each yielded Slice is freshly allocated and may be retained by the caller.
+// Nothing is yielded if k is negative or greater than len(s).
+//
+// Example:
+//
+//	Permutations(Slice[int]{1, 2, 3}, 2)(func(p Slice[int]) bool {
+//		fmt.Println(p) // [1 2] [1 3] [2 1] [2 3] [3 1] [3 2]
+//		return true
+//	})
+func Permutations[S ~[]E, E any](s S, k int) functions.Seq[Slice[E]] {
+	return func(yield func(Slice[E]) bool) {
+		n := len(s)
+		if k < 0 || k > n {
+			return
+		}
+
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		cycles := make([]int, k)
+		for i := range cycles {
+			cycles[i] = n - i
+		}
+
+		if !yield(pick(s, indices[:k])) {
+			return
+		}
+
+		for {
+			i := k - 1
+			for ; i >= 0; i-- {
+				cycles[i]--
+				if cycles[i] > 0 {
+					j := n - cycles[i]
+					indices[i], indices[j] = indices[j], indices[i]
+					if !yield(pick(s, indices[:k])) {
+						return
+					}
+					break
+				}
+				// Rotate indices[i:] left by one and reset the cycle.
+				first := indices[i]
+				copy(indices[i:], indices[i+1:])
+				indices[n-1] = first
+				cycles[i] = n - i
+			}
+			if i < 0 {
+				return
+			}
+		}
+	}
+}
+
+// Combinations returns a lazy iterator over every selection of k elements of s
+// where order does not matter and no position is used twice.
+// Selections are produced in lexicographic order of the element positions.
+//
+// Each yielded Slice is freshly allocated and may be retained by the caller.
+// Nothing is yielded if k is negative or greater than len(s).
+//
+// Example:
+//
+//	Combinations(Slice[int]{1, 2, 3}, 2)(func(c Slice[int]) bool {
+//		fmt.Println(c) // [1 2] [1 3] [2 3]
+//		return true
+//	})
+func Combinations[S ~[]E, E any](s S, k int) functions.Seq[Slice[E]] {
+	return func(yield func(Slice[E]) bool) {
+		n := len(s)
+		if k < 0 || k > n {
+			return
+		}
+
+		indices := make([]int, k)
+		for i := range indices {
+			indices[i] = i
+		}
+
+		if !yield(pick(s, indices)) {
+			return
+		}
+
+		for {
+			i := k - 1
+			for i >= 0 && indices[i] == i+n-k {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			indices[i]++
+			for j := i + 1; j < k; j++ {
+				indices[j] = indices[j-1] + 1
+			}
+			if !yield(pick(s, indices)) {
+				return
+			}
+		}
+	}
+}
+
+// CombinationsWithReplacement is like [Combinations] but allows each position of s
+// to be selected more than once.
+//
+// Each yielded Slice is freshly allocated and may be retained by the caller.
+// Nothing is yielded if k is negative, or if s is empty and k is positive.
+//
+// Example:
+//
+//	CombinationsWithReplacement(Slice[int]{1, 2}, 2)(func(c Slice[int]) bool {
+//		fmt.Println(c) // [1 1] [1 2] [2 2]
+//		return true
+//	})
+func CombinationsWithReplacement[S ~[]E, E any](s S, k int) functions.Seq[Slice[E]] {
+	return func(yield func(Slice[E]) bool) {
+		n := len(s)
+		if k < 0 || (n == 0 && k > 0) {
+			return
+		}
+
+		indices := make([]int, k)
+
+		if !yield(pick(s, indices)) {
+			return
+		}
+
+		for {
+			i := k - 1
+			for i >= 0 && indices[i] == n-1 {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			next := indices[i] + 1
+			for j := i; j < k; j++ {
+				indices[j] = next
+			}
+			if !yield(pick(s, indices)) {
+				return
+			}
+		}
+	}
+}
+
+// Product returns a lazy iterator over the Cartesian product of the given slices.
+// Each yielded Slice holds one element of every input slice, in argument order.
+// The rightmost slice advances fastest, like an odometer.
+//
+// Each yielded Slice is freshly allocated and may be retained by the caller.
+// Product of no slices yields a single empty Slice,
+// and nothing is yielded if any input slice is empty.
+//
+// Example:
+//
+//	Product(Slice[int]{1, 2}, Slice[int]{3, 4})(func(p Slice[int]) bool {
+//		fmt.Println(p) // [1 3] [1 4] [2 3] [2 4]
+//		return true
+//	})
+func Product[S ~[]E, E any](sets ...S) functions.Seq[Slice[E]] {
+	return func(yield func(Slice[E]) bool) {
+		for _, set := range sets {
+			if len(set) == 0 {
+				return
+			}
+		}
+
+		indices := make([]int, len(sets))
+		for {
+			tuple := make(Slice[E], len(sets))
+			for i, set := range sets {
+				tuple[i] = set[indices[i]]
+			}
+			if !yield(tuple) {
+				return
+			}
+
+			i := len(sets) - 1
+			for ; i >= 0; i-- {
+				indices[i]++
+				if indices[i] < len(sets[i]) {
+					break
+				}
+				indices[i] = 0
+			}
+			if i < 0 {
+				return
+			}
+		}
+	}
+}
+
+// PowerSet returns a lazy iterator over every subset of s.
+// Subsets are produced by increasing size, starting with the empty Slice,
+// and within a size in the same order as [Combinations].
+//
+// Each yielded Slice is freshly allocated and may be retained by the caller.
+//
+// Example:
+//
+//	PowerSet(Slice[int]{1, 2})(func(p Slice[int]) bool {
+//		fmt.Println(p) // [] [1] [2] [1 2]
+//		return true
+//	})
+func PowerSet[S ~[]E, E any](s S) functions.Seq[Slice[E]] {
+	return func(yield func(Slice[E]) bool) {
+		for k := 0; k <= len(s); k++ {
+			stopped := false
+			Combinations(s, k)(func(c Slice[E]) bool {
+				stopped = !yield(c)
+				return !stopped
+			})
+			if stopped {
+				return
+			}
+		}
+	}
+}
+
+// Permutations returns a lazy iterator over every ordered arrangement of k elements of the Slice.
+// See [Permutations].
+func (s Slice[T]) Permutations(k int) functions.Seq[Slice[T]] { return Permutations(s, k) }
+
+// Combinations returns a lazy iterator over every selection of k elements of the Slice.
+// See [Combinations].
+func (s Slice[T]) Combinations(k int) functions.Seq[Slice[T]] { return Combinations(s, k) }
+
+// CombinationsWithReplacement returns a lazy iterator over every selection of k elements
+// of the Slice, allowing repeats. See [CombinationsWithReplacement].
+func (s Slice[T]) CombinationsWithReplacement(k int) functions.Seq[Slice[T]] {
+	return CombinationsWithReplacement(s, k)
+}
+
+// PowerSet returns a lazy iterator over every subset of the Slice.
+// See [PowerSet].
+func (s Slice[T]) PowerSet() functions.Seq[Slice[T]] { return PowerSet(s) }
+
+// pick returns a new Slice holding the elements of s at the given positions.
+func pick[S ~[]E, E any](s S, indices []int) Slice[E] {
+	picked := make(Slice[E], len(indices))
+	for i, idx := range indices {
+		picked[i] = s[idx]
+	}
+	return picked
+}
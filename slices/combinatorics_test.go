@@ -0,0 +1,124 @@
+package slices_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cramanan/go-types/functions"
+	. "github.com/cramanan/go-types/slices"
+)
+
+func collect[T any](seq functions.Seq[Slice[T]]) (all []Slice[T]) {
+	seq(func(s Slice[T]) bool {
+		all = append(all, s)
+		return true
+	})
+	return all
+}
+
+func TestPermutations(t *testing.T) {
+	testCases := []struct {
+		desc string
+		got  []Slice[int]
+		want []Slice[int]
+	}{
+		{"Full", collect(Permutations(Slice[int]{1, 2, 3}, 3)), []Slice[int]{
+			{1, 2, 3}, {1, 3, 2}, {2, 1, 3}, {2, 3, 1}, {3, 1, 2}, {3, 2, 1},
+		}},
+		{"Partial", collect(Slice[int]{1, 2, 3}.Permutations(2)), []Slice[int]{
+			{1, 2}, {1, 3}, {2, 1}, {2, 3}, {3, 1}, {3, 2},
+		}},
+		{"Zero", collect(Permutations(Slice[int]{1, 2}, 0)), []Slice[int]{{}}},
+		{"Too many", collect(Permutations(Slice[int]{1, 2}, 3)), nil},
+		{"Negative", collect(Permutations(Slice[int]{1, 2}, -1)), nil},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !reflect.DeepEqual(tC.got, tC.want) {
+				t.Errorf("%s = %v, want %v", tC.desc, tC.got, tC.want)
+			}
+		})
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	testCases := []struct {
+		desc string
+		got  []Slice[string]
+		want []Slice[string]
+	}{
+		{"Pairs", collect(Combinations(Slice[string]{"a", "b", "c", "d"}, 2)), []Slice[string]{
+			{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"},
+		}},
+		{"All", collect(Slice[string]{"a", "b"}.Combinations(2)), []Slice[string]{{"a", "b"}}},
+		{"Zero", collect(Combinations(Slice[string]{"a"}, 0)), []Slice[string]{{}}},
+		{"Too many", collect(Combinations(Slice[string]{"a"}, 2)), nil},
+		{"With replacement", collect(CombinationsWithReplacement(Slice[string]{"a", "b", "c"}, 2)), []Slice[string]{
+			{"a", "a"}, {"a", "b"}, {"a", "c"}, {"b", "b"}, {"b", "c"}, {"c", "c"},
+		}},
+		{"With replacement empty", collect(Slice[string]{}.CombinationsWithReplacement(1)), nil},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !reflect.DeepEqual(tC.got, tC.want) {
+				t.Errorf("%s = %v, want %v", tC.desc, tC.got, tC.want)
+			}
+		})
+	}
+}
+
+func TestProduct(t *testing.T) {
+	testCases := []struct {
+		desc string
+		got  []Slice[int]
+		want []Slice[int]
+	}{
+		{"Two", collect(Product(Slice[int]{1, 2}, Slice[int]{3, 4, 5})), []Slice[int]{
+			{1, 3}, {1, 4}, {1, 5}, {2, 3}, {2, 4}, {2, 5},
+		}},
+		{"None", collect(Product[Slice[int]]()), []Slice[int]{{}}},
+		{"Empty operand", collect(Product(Slice[int]{1, 2}, Slice[int]{})), nil},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !reflect.DeepEqual(tC.got, tC.want) {
+				t.Errorf("%s = %v, want %v", tC.desc, tC.got, tC.want)
+			}
+		})
+	}
+}
+
+func TestPowerSet(t *testing.T) {
+	got := collect(Slice[int]{1, 2, 3}.PowerSet())
+	want := []Slice[int]{{}, {1}, {2}, {3}, {1, 2}, {1, 3}, {2, 3}, {1, 2, 3}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PowerSet() = %v, want %v", got, want)
+	}
+}
+
+func TestCombinatoricsEarlyStop(t *testing.T) {
+	seqs := map[string]functions.Seq[Slice[int]]{
+		"Permutations": Permutations(Slice[int]{1, 2, 3, 4}, 3),
+		"Combinations": Combinations(Slice[int]{1, 2, 3, 4}, 2),
+		"Replacement":  CombinationsWithReplacement(Slice[int]{1, 2, 3}, 2),
+		"Product":      Product(Slice[int]{1, 2}, Slice[int]{3, 4}),
+		"PowerSet":     PowerSet(Slice[int]{1, 2, 3}),
+	}
+	for desc, seq := range seqs {
+		calls := 0
+		seq(func(Slice[int]) bool {
+			calls++
+			return calls < 2
+		})
+		if calls != 2 {
+			t.Errorf("%s yielded %d times after stop, want %d", desc, calls, 2)
+		}
+	}
+}
+
+func BenchmarkPermutations(b *testing.B) {
+	s := Slice[int]{0, 1, 2, 3, 4, 5, 6, 7}
+	for i := 0; i < b.N; i++ {
+		Permutations(s, len(s))(func(Slice[int]) bool { return true })
+	}
+}
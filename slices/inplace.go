@@ -0,0 +1,55 @@
+package slices
+
+// MapInPlace is like [Slice.Map] but writes the results back into the receiver's
+// backing array instead of allocating a new slice. The modified slice is returned.
+//
+// Example:
+//
+//	s := Slice[int]{1, 2, 3}
+//	s.MapInPlace(func(v, _ int) int { return v * 2 }) // s is now [2 4 6]
+func (slice Slice[T]) MapInPlace(callbackFn func(T, int) T) Slice[T] {
+	if callbackFn == nil {
+		panic("callback function is nil")
+	}
+	for i, v := range slice {
+		slice[i] = callbackFn(v, i)
+	}
+	return slice
+}
+
+// Retain is like [Slice.Filter] but reuses the receiver's backing array.
+// It keeps the elements for which callbackFn returns true, in their original order,
+// and returns the shortened slice.
+// The callback receives the original index of each element.
+// Retain zeroes the elements between the new length and the original length.
+func (slice Slice[T]) Retain(callbackFn func(element T, index int) bool) Slice[T] {
+	if callbackFn == nil {
+		panic("callback function is nil")
+	}
+	kept := 0
+	for idx, value := range slice {
+		if callbackFn(value, idx) {
+			slice[kept] = value
+			kept++
+		}
+	}
+	var zero T
+	for i := kept; i < len(slice); i++ {
+		slice[i] = zero
+	}
+	return slice[:kept]
+}
+
+// FilterInPlace is an alias for [Slice.Retain].
+func (slice Slice[T]) FilterInPlace(callbackFn func(element T, index int) bool) Slice[T] {
+	return slice.Retain(callbackFn)
+}
+
+// FillInPlace is like [Slice.Fill] but sets every element of the receiver to value
+// instead of allocating a new slice. The modified slice is returned.
+func (s Slice[T]) FillInPlace(value T) Slice[T] {
+	for i := range s {
+		s[i] = value
+	}
+	return s
+}
@@ -0,0 +1,135 @@
+package slices_test
+
+import (
+	"testing"
+
+	. "github.com/cramanan/go-types/slices"
+)
+
+func double(v, _ int) int { return v * 2 }
+
+func even(v, _ int) bool { return v%2 == 0 }
+
+func TestPreallocated(t *testing.T) {
+	s := Slice[int]{1, 2, 3, 4}
+
+	testCases := []struct {
+		desc      string
+		got, want Slice[int]
+	}{
+		{"Map", s.Map(double), Slice[int]{2, 4, 6, 8}},
+		{"Map empty", Slice[int]{}.Map(double), nil},
+		{"Filter", s.Filter(even), Slice[int]{2, 4}},
+		{"Filter none", s.Filter(func(int, int) bool { return false }), nil},
+		{"Fill", s.Fill(7), Slice[int]{7, 7, 7, 7}},
+		{"Fill empty", Slice[int]{}.Fill(7), nil},
+		{"slices.Map", Map(s, double), Slice[int]{2, 4, 6, 8}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !Equal(tC.got, tC.want) || (tC.want == nil) != (tC.got == nil) {
+				t.Errorf("%s = %#v, want %#v", tC.desc, tC.got, tC.want)
+			}
+		})
+	}
+	if want := (Slice[int]{1, 2, 3, 4}); !Equal(s, want) {
+		t.Errorf("receiver modified: %v, want %v", s, want)
+	}
+}
+
+func TestInPlace(t *testing.T) {
+	s := Slice[int]{1, 2, 3, 4, 5}
+
+	mapped := s.MapInPlace(double)
+	if want := (Slice[int]{2, 4, 6, 8, 10}); !Equal(s, want) || &mapped[0] != &s[0] {
+		t.Errorf("MapInPlace() = %v, want %v in the same backing array", mapped, want)
+	}
+
+	s = Slice[int]{1, 2, 3, 4, 5}
+	var indices []int
+	retained := s.Retain(func(v, i int) bool {
+		indices = append(indices, i)
+		return v%2 != 0
+	})
+	if want := (Slice[int]{1, 3, 5}); !Equal(retained, want) {
+		t.Errorf("Retain() = %v, want %v", retained, want)
+	}
+	if want := (Slice[int]{1, 3, 5, 0, 0}); !Equal(s, want) {
+		t.Errorf("Retain() backing array = %v, want %v", s, want)
+	}
+	if want := []int{0, 1, 2, 3, 4}; !Equal(indices, want) {
+		t.Errorf("Retain() indices = %v, want %v", indices, want)
+	}
+
+	if got := (Slice[int]{1, 2, 3, 4}).FilterInPlace(even); !Equal(got, Slice[int]{2, 4}) {
+		t.Errorf("FilterInPlace() = %v, want %v", got, Slice[int]{2, 4})
+	}
+
+	s = Slice[int]{1, 2, 3}
+	if s.FillInPlace(9); !Equal(s, Slice[int]{9, 9, 9}) {
+		t.Errorf("FillInPlace() = %v, want %v", s, Slice[int]{9, 9, 9})
+	}
+
+	if !panics(func() { s.MapInPlace(nil) }) || !panics(func() { s.Retain(nil) }) {
+		t.Error("nil callback should panic")
+	}
+}
+
+func TestPreallocatedAllocs(t *testing.T) {
+	s := make(Slice[int], 1000)
+	testCases := []struct {
+		desc string
+		fn   func()
+		max  float64
+	}{
+		{"Map", func() { s.Map(double) }, 1},
+		{"Filter", func() { s.Filter(even) }, 1},
+		{"Fill", func() { s.Fill(1) }, 1},
+		{"slices.Map", func() { Map(s, double) }, 1},
+		{"MapInPlace", func() { s.MapInPlace(double) }, 0},
+		{"Retain", func() { s.Retain(even) }, 0},
+		{"FillInPlace", func() { s.FillInPlace(0) }, 0},
+	}
+	for _, tC := range testCases {
+		if got := testing.AllocsPerRun(10, tC.fn); got > tC.max {
+			t.Errorf("%s allocated %v times, want at most %v", tC.desc, got, tC.max)
+		}
+	}
+}
+
+var benchSink Slice[int]
+
+func BenchmarkMap(b *testing.B) {
+	s := make(Slice[int], 10000)
+	b.Run("Map", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchSink = s.Map(double)
+		}
+	})
+	b.Run("MapInPlace", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchSink = s.MapInPlace(double)
+		}
+	})
+}
+
+func BenchmarkFilter(b *testing.B) {
+	s := make(Slice[int], 10000)
+	for i := range s {
+		s[i] = i
+	}
+	b.Run("Filter", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchSink = s.Filter(even)
+		}
+	})
+	b.Run("Retain", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchSink = s.Clone().Retain(even)
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package ordered
+
+import "golang.org/x/exp/constraints"
+
+// NextPermutation rearranges s in place into the lexicographically next greater permutation.
+// It returns true if such a permutation exists.
+// Otherwise s is the last permutation: it is rearranged into the first one (sorted in ascending order)
+// and false is returned.
+//
+// Starting from a sorted slice, repeated calls visit every distinct permutation exactly once,
+// even when s contains duplicate values.
+//
+// Example:
+//
+//	s := Ordered[int]{1, 2, 3}
+//	for ok := true; ok; ok = NextPermutation(s) {
+//		fmt.Println(s) // [1 2 3] [1 3 2] [2 1 3] [2 3 1] [3 1 2] [3 2 1]
+//	}
+func NextPermutation[S ~[]O, O constraints.Ordered](s S) bool {
+	i := len(s) - 2
+	for i >= 0 && s[i] >= s[i+1] {
+		i--
+	}
+	if i >= 0 {
+		j := len(s) - 1
+		for s[j] <= s[i] {
+			j--
+		}
+		s[i], s[j] = s[j], s[i]
+	}
+	for l, r := i+1, len(s)-1; l < r; l, r = l+1, r-1 {
+		s[l], s[r] = s[r], s[l]
+	}
+	return i >= 0
+}
+
+// NextPermutation rearranges the slice in place into the lexicographically next greater permutation.
+// See [NextPermutation].
+func (s Ordered[O]) NextPermutation() bool { return NextPermutation(s) }
@@ -0,0 +1,382 @@
+package ordered
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"io"
+	"os"
+
+	"github.com/cramanan/go-types/constants"
+	"github.com/cramanan/go-types/functions"
+	"github.com/cramanan/go-types/slices"
+	"golang.org/x/exp/constraints"
+)
+
+// DefaultMemoryBudget is the memory budget used by the external sorts when none is given.
+const DefaultMemoryBudget = 64 * constants.MB
+
+// DefaultMaxFanIn is the maximum number of runs merged at once by the external sorts when none is given.
+const DefaultMaxFanIn = 64
+
+// Codec reads and writes values of type T to and from a byte stream.
+//
+// Decode must return io.EOF, and only io.EOF, when the stream ends cleanly before a new value.
+type Codec[T any] interface {
+	Encode(w io.Writer, value T) error
+	Decode(r *bufio.Reader) (T, error)
+}
+
+// BinaryCodec encodes fixed-size numeric values (int64, float32, uint16...) in little-endian byte order.
+// Architecture-dependent types such as int and uint are not supported.
+type BinaryCodec[T any] struct{}
+
+// Encode writes value to w in little-endian byte order.
+func (BinaryCodec[T]) Encode(w io.Writer, value T) error {
+	return binary.Write(w, binary.LittleEndian, value)
+}
+
+// Decode reads a value from r in little-endian byte order.
+func (BinaryCodec[T]) Decode(r *bufio.Reader) (value T, err error) {
+	err = binary.Read(r, binary.LittleEndian, &value)
+	return value, err
+}
+
+// LineCodec encodes strings as newline-terminated lines.
+// Values must not contain a newline. The last line of the input may omit its newline.
+type LineCodec[S ~string] struct{}
+
+// Encode writes value to w followed by a newline.
+func (LineCodec[S]) Encode(w io.Writer, value S) error {
+	_, err := io.WriteString(w, string(value)+"\n")
+	return err
+}
+
+// Decode reads a line from r, without its newline.
+func (LineCodec[S]) Decode(r *bufio.Reader) (S, error) {
+	line, err := r.ReadString('\n')
+	if err == io.EOF && line != "" {
+		return S(line), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return S(line[:len(line)-1]), nil
+}
+
+// ExternalOptions configures the external sorts.
+type ExternalOptions struct {
+	// MemoryBudget is the approximate number of input bytes held in memory at once,
+	// e.g. 256 * constants.MB. Each run written to disk holds about this much data.
+	// Zero means DefaultMemoryBudget.
+	//
+	// The budget counts encoded bytes, not the size of the decoded values in memory, which can be
+	// several times larger: a short line decoded by LineCodec also costs a string header.
+	// Leave room for that overhead.
+	MemoryBudget float64
+
+	// MaxFanIn is the maximum number of runs merged, and so of temporary files open, at once.
+	// When there are more runs, groups of MaxFanIn runs are first merged into longer runs.
+	// Zero means DefaultMaxFanIn; values below 2 are raised to 2.
+	MaxFanIn int
+
+	// TempDir is the directory where sorted runs are written.
+	// Empty means the default directory for temporary files.
+	TempDir string
+}
+
+// ExternalSort sorts values read from r with codec in ascending order and writes them to w with the same codec.
+// It handles inputs larger than memory: the input is split into sorted runs that fit in the
+// memory budget, the runs are written to temporary files, then k-way merged into w,
+// in several passes if there are more than MaxFanIn runs.
+// Temporary files are removed before ExternalSort returns.
+//
+// Example:
+//
+//	err := ExternalSort[int64](input, output, BinaryCodec[int64]{}, ExternalOptions{MemoryBudget: 512 * constants.MB})
+func ExternalSort[O constraints.Ordered](r io.Reader, w io.Writer, codec Codec[O], options ExternalOptions) error {
+	return ExternalSortFunc(r, w, codec, functions.Compare[O], options)
+}
+
+// ExternalSortFunc is like [ExternalSort] but orders values with cmp.
+// The sort is stable: values that compare equal keep their input order.
+func ExternalSortFunc[T any](r io.Reader, w io.Writer, codec Codec[T], cmp functions.ComparisonFunc[T], options ExternalOptions) (err error) {
+	bw := bufio.NewWriter(w)
+	ExternalSortSeq(r, codec, cmp, options)(func(value T, e error) bool {
+		if e == nil {
+			e = codec.Encode(bw, value)
+		}
+		err = e
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// ExternalSortSeq is like [ExternalSortFunc] but returns an iterator over the sorted values
+// instead of writing them. Reading and run generation start when the iterator is called.
+//
+// The iterator yields each value with a nil error. If reading, decoding or temporary file handling fails,
+// it yields the zero value with the error and stops.
+// Temporary files are removed when the iteration ends, including when it is stopped early.
+func ExternalSortSeq[T any](r io.Reader, codec Codec[T], cmp functions.ComparisonFunc[T], options ExternalOptions) functions.Seq2[T, error] {
+	if cmp == nil {
+		panic("callback function is nil")
+	}
+	budget := options.MemoryBudget
+	if budget <= 0 {
+		budget = DefaultMemoryBudget
+	}
+	fanIn := options.MaxFanIn
+	switch {
+	case fanIn == 0:
+		fanIn = DefaultMaxFanIn
+	case fanIn < 2:
+		fanIn = 2
+	}
+
+	return func(yield func(T, error) bool) {
+		var zero T
+		runs, last, err := writeRuns(r, codec, cmp, budget, options.TempDir)
+		defer func() {
+			for _, run := range runs {
+				os.Remove(run)
+			}
+		}()
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		// The whole input fit in memory: no merge needed.
+		if len(runs) == 0 {
+			for _, value := range last {
+				if !yield(value, nil) {
+					return
+				}
+			}
+			return
+		}
+
+		// Merge consecutive runs, which keeps the merge stable, until they can all be open at once.
+		for len(runs) > fanIn {
+			var merged []string
+			for i := 0; i < len(runs); i += fanIn {
+				group := runs[i:]
+				if len(group) > fanIn {
+					group = group[:fanIn]
+				}
+				run, err := mergeIntoRun(group, codec, cmp, options.TempDir)
+				if run != "" {
+					merged = append(merged, run)
+				}
+				if err != nil {
+					runs = append(merged, runs[i:]...)
+					yield(zero, err)
+					return
+				}
+				for _, name := range group {
+					os.Remove(name)
+				}
+			}
+			runs = merged
+		}
+
+		if err = mergeRuns(runs, codec, cmp, yield); err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// writeRuns splits the input into sorted runs of about budget bytes.
+// Every full run is written to a closed temporary file, whose name is returned; if nothing had to be written,
+// the sorted input is returned as last instead.
+func writeRuns[T any](r io.Reader, codec Codec[T], cmp functions.ComparisonFunc[T], budget float64, dir string) (runs []string, last []T, err error) {
+	counter := &countingReader{r: r}
+	br := bufio.NewReader(counter)
+
+	var chunk slices.Slice[T]
+	start := int64(0)
+	for {
+		value, err := codec.Decode(br)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return runs, nil, err
+		}
+		chunk = append(chunk, value)
+
+		read := counter.n - int64(br.Buffered())
+		if float64(read-start) < budget {
+			continue
+		}
+		chunk.SortStableFunc(cmp)
+		run, err := writeRun(chunk, codec, dir)
+		if run != "" {
+			runs = append(runs, run)
+		}
+		if err != nil {
+			return runs, nil, err
+		}
+		chunk, start = chunk[:0], read
+	}
+
+	chunk.SortStableFunc(cmp)
+	if len(runs) == 0 {
+		return nil, chunk, nil
+	}
+	if len(chunk) > 0 {
+		run, err := writeRun(chunk, codec, dir)
+		if run != "" {
+			runs = append(runs, run)
+		}
+		if err != nil {
+			return runs, nil, err
+		}
+	}
+	return runs, nil, nil
+}
+
+// writeRun writes a sorted chunk to a new temporary file and closes it.
+// The name of the file is returned even on error, so that the caller removes it.
+func writeRun[T any](chunk []T, codec Codec[T], dir string) (string, error) {
+	return writeRunSeq(func(yield func(T) bool) error {
+		for _, value := range chunk {
+			if !yield(value) {
+				break
+			}
+		}
+		return nil
+	}, codec, dir)
+}
+
+// mergeIntoRun merges sorted runs into a new temporary file, like [writeRun].
+func mergeIntoRun[T any](runs []string, codec Codec[T], cmp functions.ComparisonFunc[T], dir string) (string, error) {
+	return writeRunSeq(func(yield func(T) bool) error {
+		return mergeRuns(runs, codec, cmp, func(value T, _ error) bool { return yield(value) })
+	}, codec, dir)
+}
+
+// writeRunSeq writes the values produced by values to a new temporary file and closes it.
+// values must stop early when yield returns false, which happens on an encoding error.
+func writeRunSeq[T any](values func(yield func(T) bool) error, codec Codec[T], dir string) (name string, err error) {
+	file, err := os.CreateTemp(dir, "go-types-run-*")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	bw := bufio.NewWriter(file)
+	var encodeErr error
+	err = values(func(value T) bool {
+		encodeErr = codec.Encode(bw, value)
+		return encodeErr == nil
+	})
+	if err == nil {
+		err = encodeErr
+	}
+	if err == nil {
+		err = bw.Flush()
+	}
+	return file.Name(), err
+}
+
+// mergeRuns k-way merges the sorted runs and yields every value in order.
+// The runs are open only during the merge.
+func mergeRuns[T any](runs []string, codec Codec[T], cmp functions.ComparisonFunc[T], yield func(T, error) bool) error {
+	h := &runHeap[T]{cmp: cmp}
+	for i, name := range runs {
+		run, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer run.Close()
+		head := &runHead[T]{index: i, reader: bufio.NewReader(run)}
+		ok, err := head.next(codec)
+		if err != nil {
+			return err
+		}
+		if ok {
+			h.heads = append(h.heads, head)
+		}
+	}
+	heap.Init(h)
+
+	for h.Len() > 0 {
+		head := h.heads[0]
+		if !yield(head.value, nil) {
+			return nil
+		}
+		ok, err := head.next(codec)
+		if err != nil {
+			return err
+		}
+		if ok {
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+	}
+	return nil
+}
+
+// runHead is the next unread value of a run.
+type runHead[T any] struct {
+	index  int
+	reader *bufio.Reader
+	value  T
+}
+
+// next decodes the next value of the run and reports false at the end of the run.
+func (r *runHead[T]) next(codec Codec[T]) (bool, error) {
+	value, err := codec.Decode(r.reader)
+	if err == io.EOF {
+		return false, nil
+	}
+	r.value = value
+	return err == nil, err
+}
+
+// runHeap is a min-heap of run heads. Ties are broken by run index to keep the merge stable.
+type runHeap[T any] struct {
+	heads []*runHead[T]
+	cmp   functions.ComparisonFunc[T]
+}
+
+func (h runHeap[T]) Len() int { return len(h.heads) }
+
+func (h runHeap[T]) Less(i, j int) bool {
+	if order := h.cmp(h.heads[i].value, h.heads[j].value); order != 0 {
+		return order < 0
+	}
+	return h.heads[i].index < h.heads[j].index
+}
+
+func (h runHeap[T]) Swap(i, j int) { h.heads[i], h.heads[j] = h.heads[j], h.heads[i] }
+
+func (h *runHeap[T]) Push(x any) { h.heads = append(h.heads, x.(*runHead[T])) }
+
+func (h *runHeap[T]) Pop() any {
+	last := h.heads[len(h.heads)-1]
+	h.heads = h.heads[:len(h.heads)-1]
+	return last
+}
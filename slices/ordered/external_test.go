@@ -0,0 +1,96 @@
+package ordered_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/cramanan/go-types/constants"
+	"github.com/cramanan/go-types/functions"
+	. "github.com/cramanan/go-types/slices/ordered"
+)
+
+func TestExternalSort(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	values := make([]int64, 10000)
+	var input bytes.Buffer
+	for i := range values {
+		values[i] = r.Int63n(1000) - 500
+		binary.Write(&input, binary.LittleEndian, values[i])
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	dir := t.TempDir()
+	var output bytes.Buffer
+	// 8 KB of budget for 80 KB of input forces about ten runs, merged in three passes.
+	options := ExternalOptions{MemoryBudget: 8 * constants.KB, TempDir: dir, MaxFanIn: 3}
+	if err := ExternalSort[int64](&input, &output, BinaryCodec[int64]{}, options); err != nil {
+		t.Fatal(err)
+	}
+
+	got := make([]int64, len(values))
+	if err := binary.Read(&output, binary.LittleEndian, got); err != nil {
+		t.Fatal(err)
+	}
+	if !eq(got, values) {
+		t.Error("ExternalSort() output is not sorted")
+	}
+
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("ExternalSort() left %d temporary files", len(entries))
+	}
+}
+
+func TestExternalSortFunc(t *testing.T) {
+	// A budget of 10 bytes writes a run every line or two; small fan-ins force intermediate merge passes.
+	for _, fanIn := range []int{0, 2, 3} {
+		input := strings.NewReader("pear\nApple\nfig\napple\nBanana\nbanana")
+		var output strings.Builder
+
+		dir := t.TempDir()
+		options := ExternalOptions{MemoryBudget: 10, TempDir: dir, MaxFanIn: fanIn}
+		err := ExternalSortFunc[string](input, &output, LineCodec[string]{}, functions.CaseInsensitive[string], options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "Apple\napple\nBanana\nbanana\nfig\npear\n"; output.String() != want {
+			t.Errorf("ExternalSortFunc() with MaxFanIn %d = %q, want %q", fanIn, output.String(), want)
+		}
+		if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+			t.Errorf("ExternalSortFunc() with MaxFanIn %d left %d temporary files", fanIn, len(entries))
+		}
+	}
+}
+
+func TestExternalSortSeq(t *testing.T) {
+	dir := t.TempDir()
+	input := strings.NewReader("c\na\nd\nb\ne\n")
+	seq := ExternalSortSeq[string](input, LineCodec[string]{}, functions.Compare[string], ExternalOptions{MemoryBudget: 2, TempDir: dir})
+
+	var got []string
+	seq(func(value string, err error) bool {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, value)
+		return len(got) < 3
+	})
+	if want := []string{"a", "b", "c"}; !eq(got, want) {
+		t.Errorf("ExternalSortSeq() = %v, want %v", got, want)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("ExternalSortSeq() left %d temporary files after early stop", len(entries))
+	}
+
+	truncated := bytes.NewReader([]byte{1, 0, 0, 0, 0, 0, 0, 0, 2, 0})
+	err := ExternalSort[int64](truncated, new(bytes.Buffer), BinaryCodec[int64]{}, ExternalOptions{})
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("ExternalSort(truncated) error = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
@@ -0,0 +1,207 @@
+package ordered
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cramanan/go-types/maps"
+	"golang.org/x/exp/constraints"
+)
+
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+// Strategy defines how [Histogram] places the bucket edges.
+type Strategy int
+
+const (
+	// EqualWidth splits the range between the minimum and the maximum into buckets of the same width.
+	EqualWidth Strategy = iota
+
+	// EqualFrequency places the edges at quantiles so that every bucket holds about the same number of values.
+	// Edges that would be repeated because of ties are merged, so fewer buckets may be returned.
+	EqualFrequency
+)
+
+// Range is the interval covered by a bucket, from Low (inclusive) to High (exclusive).
+// The last bucket of a histogram also includes its High edge.
+type Range struct{ Low, High float64 }
+
+// Bucket pairs a Range with the number of values that fall in it.
+type Bucket struct {
+	Range
+	Count int
+}
+
+// Buckets is the result of a histogram: len(Edges)-1 consecutive buckets and their counts.
+type Buckets struct {
+	// Edges are the sorted bucket boundaries. Bucket i covers [Edges[i], Edges[i+1]).
+	Edges Ordered[float64]
+
+	// Counts holds the number of values in each bucket.
+	Counts []int
+}
+
+// Histogram counts the values of s into the given number of buckets, with edges placed according to strategy.
+// NaN and infinite values are ignored, since they cannot be placed in buckets of finite width.
+// An empty slice or a non-positive number of buckets returns empty Buckets.
+//
+// Example:
+//
+//	h := Histogram(New(1, 2, 2, 3, 9), 4, EqualWidth)
+//	h.Edges  // [1 3 5 7 9]
+//	h.Counts // [3 1 0 1]
+func Histogram[N Number](s Ordered[N], buckets int, strategy Strategy) Buckets {
+	values := make(Ordered[float64], 0, len(s))
+	for _, v := range s {
+		if f := float64(v); !math.IsNaN(f) && !math.IsInf(f, 0) {
+			values = append(values, f)
+		}
+	}
+	if len(values) == 0 || buckets <= 0 {
+		return Buckets{}
+	}
+	values = values.Sort()
+	lo, hi := values[0], values[len(values)-1]
+
+	var edges Ordered[float64]
+	switch {
+	case lo == hi:
+		edges = Ordered[float64]{lo, hi}
+	case strategy == EqualFrequency:
+		edges = make(Ordered[float64], 0, buckets+1)
+		for i := 0; i < buckets; i++ {
+			edges = append(edges, values[i*len(values)/buckets])
+		}
+		edges = append(edges, hi).Compact()
+	default:
+		// Scaling before adding avoids overflowing when hi - lo is larger than the largest float64.
+		b := float64(buckets)
+		edges = make(Ordered[float64], buckets+1)
+		for i := range edges {
+			edges[i] = lo/b*(b-float64(i)) + hi/b*float64(i)
+		}
+		edges[buckets] = hi
+	}
+
+	return countInto(edges, values)
+}
+
+// HistogramEdges counts the values of s into the buckets delimited by the given edges,
+// which are sorted first. Values outside of [edges[0], edges[len(edges)-1]] and NaN values are ignored.
+// NaN edges cannot be sorted: if there is one, HistogramEdges returns empty Buckets.
+func HistogramEdges[N Number](s Ordered[N], edges ...float64) Buckets {
+	for _, edge := range edges {
+		if math.IsNaN(edge) {
+			return Buckets{}
+		}
+	}
+	sorted := Ordered[float64](edges).Sort().Compact()
+	if len(sorted) < 2 {
+		return Buckets{}
+	}
+	values := make(Ordered[float64], len(s))
+	for i, v := range s {
+		values[i] = float64(v)
+	}
+	return countInto(sorted, values)
+}
+
+// countInto builds Buckets from sorted edges and counts values into them.
+func countInto(edges, values Ordered[float64]) Buckets {
+	b := Buckets{Edges: edges, Counts: make([]int, len(edges)-1)}
+	for _, v := range values {
+		if i := b.Bucketize(v); i >= 0 {
+			b.Counts[i]++
+		}
+	}
+	return b
+}
+
+// Len returns the number of buckets.
+func (b Buckets) Len() int { return len(b.Counts) }
+
+// Bucketize returns the index of the bucket containing value, or -1 if value is outside of every bucket.
+// The lookup is a binary search over the edges.
+func (b Buckets) Bucketize(value float64) int {
+	if len(b.Edges) < 2 || math.IsNaN(value) {
+		return -1
+	}
+	i, found := b.Edges.BinarySearch(value)
+	last := len(b.Edges) - 1
+	switch {
+	case found && i < last:
+		return i
+	case found:
+		return last - 1
+	case i == 0 || i > last:
+		return -1
+	}
+	return i - 1
+}
+
+// Buckets returns the buckets as a slice of ranges and counts, in ascending order.
+func (b Buckets) Buckets() []Bucket {
+	buckets := make([]Bucket, len(b.Counts))
+	for i, count := range b.Counts {
+		buckets[i] = Bucket{Range{b.Edges[i], b.Edges[i+1]}, count}
+	}
+	return buckets
+}
+
+// Map returns the counts indexed by bucket range.
+func (b Buckets) Map() maps.Map[Range, int] {
+	m := maps.New[Range, int]()
+	for _, bucket := range b.Buckets() {
+		m[bucket.Range] = bucket.Count
+	}
+	return m
+}
+
+// Chart renders the buckets as an ASCII bar chart, one line per bucket.
+// The longest bar is width characters long; a negative width draws no bars.
+//
+// Example:
+//
+//	fmt.Print(Histogram(New(1, 2, 2, 3, 9), 4, EqualWidth).Chart(10))
+//	// [1, 3) ########## 3
+//	// [3, 5) ###        1
+//	// [5, 7)            0
+//	// [7, 9] ###        1
+func (b Buckets) Chart(width int) string {
+	labels := make([]string, len(b.Counts))
+	labelWidth, maxCount := 0, 0
+	for i, bucket := range b.Buckets() {
+		closing := ")"
+		if i == len(b.Counts)-1 {
+			closing = "]"
+		}
+		labels[i] = "[" + formatEdge(bucket.Low) + ", " + formatEdge(bucket.High) + closing
+		if len(labels[i]) > labelWidth {
+			labelWidth = len(labels[i])
+		}
+		if bucket.Count > maxCount {
+			maxCount = bucket.Count
+		}
+	}
+
+	if width < 0 {
+		width = 0
+	}
+	var chart strings.Builder
+	for i, count := range b.Counts {
+		bar := 0
+		if maxCount > 0 {
+			bar = int(math.Round(float64(count) * float64(width) / float64(maxCount)))
+		}
+		fmt.Fprintf(&chart, "%-*s %-*s %d\n", labelWidth, labels[i], width, strings.Repeat("#", bar), count)
+	}
+	return chart.String()
+}
+
+// formatEdge formats an edge with the fewest digits needed.
+func formatEdge(edge float64) string { return strconv.FormatFloat(edge, 'g', -1, 64) }
@@ -0,0 +1,99 @@
+package ordered_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cramanan/go-types/maps"
+	. "github.com/cramanan/go-types/slices/ordered"
+)
+
+func TestHistogram(t *testing.T) {
+	s := New(1, 2, 2, 3, 9)
+
+	h := Histogram(s, 4, EqualWidth)
+	if want := New[float64](1, 3, 5, 7, 9); !eq(h.Edges, want) {
+		t.Errorf("EqualWidth edges = %v, want %v", h.Edges, want)
+	}
+	if want := []int{3, 1, 0, 1}; !eq(h.Counts, want) {
+		t.Errorf("EqualWidth counts = %v, want %v", h.Counts, want)
+	}
+
+	h = Histogram(New(1.0, 2, 3, 4, 5, 6, math.NaN()), 3, EqualFrequency)
+	if want := New[float64](1, 3, 5, 6); !eq(h.Edges, want) {
+		t.Errorf("EqualFrequency edges = %v, want %v", h.Edges, want)
+	}
+	if want := []int{2, 2, 2}; !eq(h.Counts, want) {
+		t.Errorf("EqualFrequency counts = %v, want %v", h.Counts, want)
+	}
+
+	h = Histogram(New(math.Inf(-1), 1, 2, 3, math.Inf(1)), 2, EqualWidth)
+	if want := New[float64](1, 2, 3); !eq(h.Edges, want) {
+		t.Errorf("EqualWidth edges with infinities = %v, want %v", h.Edges, want)
+	}
+	if want := []int{1, 2}; !eq(h.Counts, want) {
+		t.Errorf("EqualWidth counts with infinities = %v, want %v", h.Counts, want)
+	}
+	h = Histogram(New(-math.MaxFloat64, 0, math.MaxFloat64), 4, EqualWidth)
+	for i, edge := range h.Edges {
+		if math.IsInf(edge, 0) || math.IsNaN(edge) || (i > 0 && edge <= h.Edges[i-1]) {
+			t.Errorf("EqualWidth edges of the widest range = %v, want finite increasing edges", h.Edges)
+			break
+		}
+	}
+	if want := []int{1, 0, 1, 1}; !eq(h.Counts, want) {
+		t.Errorf("EqualWidth counts of the widest range = %v, want %v", h.Counts, want)
+	}
+	if h = HistogramEdges(s, 0, math.NaN(), 10); h.Len() != 0 {
+		t.Errorf("HistogramEdges(NaN edge) = %+v, want empty Buckets", h)
+	}
+	if h = Histogram(New(math.Inf(1), math.NaN()), 2, EqualFrequency); h.Len() != 0 {
+		t.Errorf("Histogram(non-finite) = %+v, want empty Buckets", h)
+	}
+
+	h = HistogramEdges(s, 10, 0, 2)
+	if want := []int{1, 4}; !eq(h.Counts, want) {
+		t.Errorf("HistogramEdges counts = %v, want %v", h.Counts, want)
+	}
+	want := maps.Map[Range, int]{{0, 2}: 1, {2, 10}: 4}
+	if got := h.Map(); !maps.Equal(got, want) {
+		t.Errorf("Map() = %v, want %v", got, want)
+	}
+
+	if h = Histogram(New(4, 4, 4), 3, EqualWidth); h.Len() != 1 || h.Counts[0] != 3 {
+		t.Errorf("Histogram(constant) = %+v, want a single bucket of 3", h)
+	}
+	if h = Histogram(New[int](), 3, EqualWidth); h.Len() != 0 {
+		t.Errorf("Histogram(empty) = %+v, want no bucket", h)
+	}
+}
+
+func TestBucketize(t *testing.T) {
+	h := HistogramEdges(New[int](), 0, 10, 20)
+	testCases := []struct {
+		value float64
+		want  int
+	}{
+		{-1, -1}, {0, 0}, {5, 0}, {10, 1}, {19.9, 1}, {20, 1}, {20.1, -1}, {math.NaN(), -1},
+	}
+	for _, tC := range testCases {
+		if got := h.Bucketize(tC.value); got != tC.want {
+			t.Errorf("Bucketize(%v) = %d, want %d", tC.value, got, tC.want)
+		}
+	}
+}
+
+func TestChart(t *testing.T) {
+	got := Histogram(New(1, 2, 2, 3, 9), 4, EqualWidth).Chart(6)
+	want := "" +
+		"[1, 3) ###### 3\n" +
+		"[3, 5) ##     1\n" +
+		"[5, 7)        0\n" +
+		"[7, 9] ##     1\n"
+	if got != want {
+		t.Errorf("Chart() =\n%s\nwant\n%s", got, want)
+	}
+	if got, want := HistogramEdges(New(1), 0, 2).Chart(-1), "[0, 2]  1\n"; got != want {
+		t.Errorf("Chart(-1) = %q, want %q", got, want)
+	}
+}
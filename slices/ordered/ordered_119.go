@@ -161,9 +161,12 @@ func (s Ordered[O]) SortFunc(cmp func(a, b O) int) {
 //
 // The Sort method creates a clone of the original Ordered slice, sorts it using the slices.Sort function,
 // and returns the sorted clone. The original slice remains unchanged.
+// Large slices of integers are sorted with [RadixSort] instead.
 func (s Ordered[O]) Sort() Ordered[O] {
 	clone := s.Clone()
-	slices.Sort(clone)
+	if len(clone) < radixThreshold || !sortIntegers(clone) {
+		slices.Sort(clone)
+	}
 	return clone
 }
 
@@ -189,3 +189,78 @@ func TestMap(t *testing.T) {
 	}()
 	New(870987, 7697869876, 658678675).Map(nil)
 }
+
+func TestNextPermutation(t *testing.T) {
+	s := New(1, 2, 2)
+	var got []Ordered[int]
+	for ok := true; ok; ok = s.NextPermutation() {
+		got = append(got, s.Clone())
+	}
+	want := []Ordered[int]{{1, 2, 2}, {2, 1, 2}, {2, 2, 1}}
+	if len(got) != len(want) {
+		t.Fatalf("NextPermutation visited %v, want %v", got, want)
+	}
+	for i := range want {
+		if !eq(got[i], want[i]) {
+			t.Errorf("NextPermutation step %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+	if !eq(s, want[0]) {
+		t.Errorf("NextPermutation wrap-around = %v, want %v", s, want[0])
+	}
+
+	if NextPermutation(New[int]()) {
+		t.Error("NextPermutation(empty) = true, want false")
+	}
+}
+
+func TestBounds(t *testing.T) {
+	s := New(1, 2, 2, 2, 5, 8)
+
+	testCases := []struct {
+		desc      string
+		got, want int
+	}{
+		{"LowerBound", s.LowerBound(2), 1},
+		{"LowerBound absent", s.LowerBound(3), 4},
+		{"LowerBound past end", s.LowerBound(9), 6},
+		{"UpperBound", s.UpperBound(2), 4},
+		{"UpperBound before start", UpperBound(s, 0), 0},
+		{"CountInRange", s.CountInRange(2, 6), 4},
+		{"CountInRange empty", s.CountInRange(6, 2), 0},
+		{"CountInRange excludes hi", CountInRange(s, 1, 2), 1},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if tC.got != tC.want {
+				t.Errorf("%s = %d, want %d", tC.desc, tC.got, tC.want)
+			}
+		})
+	}
+
+	if first, last := s.EqualRange(2); first != 1 || last != 4 {
+		t.Errorf("EqualRange(2) = %d, %d, want %d, %d", first, last, 1, 4)
+	}
+	if first, last := EqualRange(s, 4); first != 4 || last != 4 {
+		t.Errorf("EqualRange(4) = %d, %d, want %d, %d", first, last, 4, 4)
+	}
+}
+
+func TestParallelSort(t *testing.T) {
+	s := make(Ordered[int], 20000)
+	for i := range s {
+		s[i] = (i * 7919) % 10007
+	}
+	sorted := s.ParallelSort()
+	if !sorted.IsSorted() || !eq(sorted, s.Sort()) {
+		t.Error("ParallelSort() is not sorted")
+	}
+	if s.IsSorted() {
+		t.Error("ParallelSort() modified the receiver")
+	}
+
+	s.ParallelSortStableFunc(func(a, b int) int { return b - a })
+	if !eq(s, sorted.Reverse()) {
+		t.Error("ParallelSortStableFunc() is not sorted in descending order")
+	}
+}
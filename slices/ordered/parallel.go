@@ -0,0 +1,22 @@
+package ordered
+
+import "github.com/cramanan/go-types/slices"
+
+// ParallelSort returns a new sorted Ordered slice, sorting the clone across
+// up to GOMAXPROCS goroutines. The original slice remains unchanged.
+// Small slices are sorted on the calling goroutine.
+func (s Ordered[O]) ParallelSort() Ordered[O] {
+	clone := s.Clone()
+	slices.ParallelSort(clone)
+	return clone
+}
+
+// ParallelSortFunc sorts the slice in place in ascending order as determined by the cmp function,
+// using concurrent goroutines. See [slices.ParallelSortFunc].
+func (s Ordered[O]) ParallelSortFunc(cmp func(a, b O) int) { slices.ParallelSortFunc(s, cmp) }
+
+// ParallelSortStableFunc is like [Ordered.ParallelSortFunc] but keeps the original order of equal elements.
+// See [slices.ParallelSortStableFunc].
+func (s Ordered[O]) ParallelSortStableFunc(cmp func(a, b O) int) {
+	slices.ParallelSortStableFunc(s, cmp)
+}
@@ -0,0 +1,164 @@
+package ordered
+
+import (
+	"unsafe"
+
+	"golang.org/x/exp/constraints"
+)
+
+// radixThreshold is the length from which [Ordered.Sort] switches to [RadixSort] for integer slices.
+// Below it, the comparison sort is faster than the radix passes and their buffers.
+const radixThreshold = 1 << 12
+
+// RadixSort sorts a slice of integers in ascending order using an LSD radix sort.
+// It runs in O(n·w) time, where w is the size of the integer type in bytes,
+// and allocates two buffers of len(s) words.
+// Byte positions shared by every element are skipped, so small ranges sort in fewer passes.
+func RadixSort[S ~[]I, I constraints.Integer](s S) {
+	if len(s) < 2 {
+		return
+	}
+	size, flip := integerLayout[I]()
+	keys := make([]uint64, len(s))
+	for i, v := range s {
+		keys[i] = uint64(v)&widthMask(size) ^ flip
+	}
+	keys = radixPasses(keys, size)
+	for i, k := range keys {
+		s[i] = I(k ^ flip)
+	}
+}
+
+// RadixSortStrings sorts a slice of strings in ascending byte-wise order using an MSD radix sort.
+// The resulting order is the same as with the < operator.
+// It is most effective on large slices of short keys such as ASCII identifiers.
+func RadixSortStrings[S ~[]E, E ~string](s S) {
+	if len(s) < 2 {
+		return
+	}
+	msdSort(s, make(S, len(s)), 0)
+}
+
+// sortIntegers radix sorts s and reports true if its element type is one of the predeclared integer types.
+func sortIntegers[O constraints.Ordered](s []O) bool {
+	switch v := any(s).(type) {
+	case []int:
+		RadixSort(v)
+	case []int8:
+		RadixSort(v)
+	case []int16:
+		RadixSort(v)
+	case []int32:
+		RadixSort(v)
+	case []int64:
+		RadixSort(v)
+	case []uint:
+		RadixSort(v)
+	case []uint8:
+		RadixSort(v)
+	case []uint16:
+		RadixSort(v)
+	case []uint32:
+		RadixSort(v)
+	case []uint64:
+		RadixSort(v)
+	case []uintptr:
+		RadixSort(v)
+	default:
+		return false
+	}
+	return true
+}
+
+// integerLayout returns the size in bytes of I and the key bit to flip so that
+// signed values sort correctly as unsigned keys.
+func integerLayout[I constraints.Integer]() (size int, flip uint64) {
+	size = int(unsafe.Sizeof(I(0)))
+	if ^I(0) < 0 {
+		flip = 1 << (8*size - 1)
+	}
+	return size, flip
+}
+
+// widthMask returns a mask of the low size bytes.
+func widthMask(size int) uint64 {
+	if size >= 8 {
+		return ^uint64(0)
+	}
+	return 1<<(8*size) - 1
+}
+
+// radixPasses sorts keys with one counting pass per byte and returns the sorted slice,
+// which may be keys or its buffer.
+func radixPasses(keys []uint64, size int) []uint64 {
+	buf := make([]uint64, len(keys))
+	for shift := 0; shift < 8*size; shift += 8 {
+		var count [256]int
+		for _, k := range keys {
+			count[byte(k>>shift)]++
+		}
+		if count[byte(keys[0]>>shift)] == len(keys) {
+			continue
+		}
+		offset := 0
+		for b, c := range count {
+			count[b] = offset
+			offset += c
+		}
+		for _, k := range keys {
+			b := byte(k >> shift)
+			buf[count[b]] = k
+			count[b]++
+		}
+		keys, buf = buf, keys
+	}
+	return keys
+}
+
+// msdSort sorts s by the bytes from depth onwards, using tmp as scratch space.
+func msdSort[S ~[]E, E ~string](s, tmp S, depth int) {
+	if len(s) < 32 {
+		insertionSortFrom(s, depth)
+		return
+	}
+	// Bucket 0 holds the strings that end at depth, bucket b+1 the strings whose byte is b.
+	var count [258]int
+	for _, v := range s {
+		count[bucketAt(v, depth)+1]++
+	}
+	for b := 1; b < len(count); b++ {
+		count[b] += count[b-1]
+	}
+	for _, v := range s {
+		b := bucketAt(v, depth)
+		tmp[count[b]] = v
+		count[b]++
+	}
+	copy(s, tmp[:len(s)])
+
+	start := count[0]
+	for b := 1; b < 257; b++ {
+		end := count[b]
+		if end-start > 1 {
+			msdSort(s[start:end], tmp, depth+1)
+		}
+		start = end
+	}
+}
+
+// bucketAt returns 0 if v ends before depth, or the byte at depth plus one.
+func bucketAt[E ~string](v E, depth int) int {
+	if depth >= len(v) {
+		return 0
+	}
+	return int(v[depth]) + 1
+}
+
+// insertionSortFrom sorts a small slice of strings sharing their first depth bytes.
+func insertionSortFrom[S ~[]E, E ~string](s S, depth int) {
+	for i := 1; i < len(s); i++ {
+		for j := i; j > 0 && s[j][depth:] < s[j-1][depth:]; j-- {
+			s[j], s[j-1] = s[j-1], s[j]
+		}
+	}
+}
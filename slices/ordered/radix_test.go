@@ -0,0 +1,95 @@
+package ordered_test
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	. "github.com/cramanan/go-types/slices/ordered"
+)
+
+func TestRadixSort(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	ints := make([]int, 5000)
+	for i := range ints {
+		ints[i] = r.Int() - math.MaxInt/2
+	}
+	ints = append(ints, math.MinInt, math.MaxInt, 0, -1)
+	want := append([]int(nil), ints...)
+	sort.Ints(want)
+	RadixSort(ints)
+	if !eq(ints, want) {
+		t.Error("RadixSort([]int) is not sorted")
+	}
+
+	int8s := []int8{5, -128, 127, 0, -1, 3, -3}
+	RadixSort(int8s)
+	if want := []int8{-128, -3, -1, 0, 3, 5, 127}; !eq(int8s, want) {
+		t.Errorf("RadixSort([]int8) = %v, want %v", int8s, want)
+	}
+
+	uint16s := New[uint16](65535, 256, 1, 0, 257)
+	RadixSort(uint16s)
+	if want := New[uint16](0, 1, 256, 257, 65535); !eq(uint16s, want) {
+		t.Errorf("RadixSort([]uint16) = %v, want %v", uint16s, want)
+	}
+}
+
+func TestRadixSortStrings(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	const letters = "abcAB_0"
+
+	s := make([]string, 3000)
+	for i := range s {
+		b := make([]byte, r.Intn(6))
+		for j := range b {
+			b[j] = letters[r.Intn(len(letters))]
+		}
+		s[i] = string(b)
+	}
+	want := append([]string(nil), s...)
+	sort.Strings(want)
+
+	RadixSortStrings(s)
+	if !eq(s, want) {
+		t.Error("RadixSortStrings() is not sorted")
+	}
+}
+
+func TestSortSwitchesToRadix(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	s := make(Ordered[int64], 4096)
+	for i := range s {
+		s[i] = r.Int63() - math.MaxInt64/2
+	}
+	sorted := s.Sort()
+	if !sorted.IsSorted() {
+		t.Error("Sort() is not sorted")
+	}
+	if s.IsSorted() {
+		t.Error("Sort() modified the receiver")
+	}
+}
+
+func BenchmarkSortInts(b *testing.B) {
+	r := rand.New(rand.NewSource(4))
+	s := make(Ordered[int], 1<<16)
+	for i := range s {
+		s[i] = r.Int()
+	}
+	work := make([]int, len(s))
+	b.Run("Radix", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(work, s)
+			RadixSort(work)
+		}
+	})
+	b.Run("Comparison", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(work, s)
+			sort.Ints(work)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package ordered
+
+import (
+	"github.com/cramanan/go-types/functions"
+	"golang.org/x/exp/constraints"
+)
+
+// LowerBound returns the index of the first element of s that is not less than target,
+// or len(s) if there is none. The slice must be sorted in increasing order.
+//
+// For floating-point types, a NaN is considered less than any non-NaN.
+func LowerBound[S ~[]O, O constraints.Ordered](s S, target O) int {
+	lo, hi := 0, len(s)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if functions.Less(s[mid], target) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// UpperBound returns the index of the first element of s that is greater than target,
+// or len(s) if there is none. The slice must be sorted in increasing order.
+//
+// For floating-point types, a NaN is considered less than any non-NaN.
+func UpperBound[S ~[]O, O constraints.Ordered](s S, target O) int {
+	lo, hi := 0, len(s)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if functions.Greater(s[mid], target) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// EqualRange returns the bounds of the run of elements equal to target,
+// so that s[first:last] holds every occurrence of target.
+// If target is absent, first == last is the position where it would be inserted.
+// The slice must be sorted in increasing order.
+func EqualRange[S ~[]O, O constraints.Ordered](s S, target O) (first, last int) {
+	return LowerBound(s, target), UpperBound(s, target)
+}
+
+// CountInRange returns the number of elements v of s such that lo <= v < hi.
+// The slice must be sorted in increasing order. It returns 0 if hi <= lo.
+func CountInRange[S ~[]O, O constraints.Ordered](s S, lo, hi O) int {
+	if !functions.Less(lo, hi) {
+		return 0
+	}
+	return LowerBound(s, hi) - LowerBound(s, lo)
+}
+
+// LowerBound returns the index of the first element that is not less than target.
+// See [LowerBound].
+func (s Ordered[O]) LowerBound(target O) int { return LowerBound(s, target) }
+
+// UpperBound returns the index of the first element that is greater than target.
+// See [UpperBound].
+func (s Ordered[O]) UpperBound(target O) int { return UpperBound(s, target) }
+
+// EqualRange returns the bounds of the run of elements equal to target.
+// See [EqualRange].
+func (s Ordered[O]) EqualRange(target O) (first, last int) { return EqualRange(s, target) }
+
+// CountInRange returns the number of elements v such that lo <= v < hi.
+// See [CountInRange].
+func (s Ordered[O]) CountInRange(lo, hi O) int { return CountInRange(s, lo, hi) }
@@ -0,0 +1,206 @@
+package ordered
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/cramanan/go-types/functions"
+	"golang.org/x/exp/constraints"
+)
+
+// Sketch is a streaming quantile summary of ordered values (Greenwald-Khanna).
+//
+// Values are added one at a time and only O(1/ε·log(εn)) of them are kept,
+// yet every rank answered by the sketch is within ε·n of the exact rank,
+// where n is the number of values added and ε is the epsilon of the sketch.
+//
+// A Sketch is not safe for concurrent use.
+type Sketch[O constraints.Ordered] struct {
+	epsilon float64
+	count   int
+	tuples  []sketchTuple[O]
+	pending int
+}
+
+// sketchTuple is a kept value with g, the difference between its minimal rank and the previous one,
+// and delta, the uncertainty on its rank.
+type sketchTuple[O constraints.Ordered] struct {
+	Value O
+	G     int
+	Delta int
+}
+
+// NewSketch returns an empty Sketch answering ranks within epsilon·n, e.g. 0.01 for 1%.
+// It panics if epsilon is not in (0, 1).
+func NewSketch[O constraints.Ordered](epsilon float64) *Sketch[O] {
+	if !(epsilon > 0 && epsilon < 1) {
+		panic("sketch epsilon must be between 0 and 1")
+	}
+	return &Sketch[O]{epsilon: epsilon}
+}
+
+// SketchFrom returns a Sketch of epsilon holding every value of s.
+// Since the values are known up front, the sketch is built from a sorted copy in one pass.
+func SketchFrom[O constraints.Ordered](s Ordered[O], epsilon float64) *Sketch[O] {
+	sketch := NewSketch[O](epsilon)
+	sorted := s.Sort()
+	sketch.tuples = make([]sketchTuple[O], len(sorted))
+	for i, v := range sorted {
+		sketch.tuples[i] = sketchTuple[O]{v, 1, 0}
+	}
+	sketch.count = len(sorted)
+	sketch.compress()
+	return sketch
+}
+
+// Sketch returns a Sketch of epsilon holding every value of the slice. See [SketchFrom].
+func (s Ordered[O]) Sketch(epsilon float64) *Sketch[O] { return SketchFrom(s, epsilon) }
+
+// Epsilon returns the rank error of the sketch, as a fraction of Count.
+func (s *Sketch[O]) Epsilon() float64 { return s.epsilon }
+
+// Count returns the number of values added to the sketch.
+func (s *Sketch[O]) Count() int { return s.count }
+
+// Insert adds a value to the sketch.
+// For floating-point types, a NaN is considered less than any non-NaN.
+func (s *Sketch[O]) Insert(value O) {
+	i := sort.Search(len(s.tuples), func(i int) bool { return functions.Less(value, s.tuples[i].Value) })
+
+	delta := 0
+	if i > 0 && i < len(s.tuples) {
+		delta = s.tuples[i].G + s.tuples[i].Delta - 1
+	}
+	s.tuples = append(s.tuples, sketchTuple[O]{})
+	copy(s.tuples[i+1:], s.tuples[i:])
+	s.tuples[i] = sketchTuple[O]{value, 1, delta}
+	s.count++
+
+	s.pending++
+	if float64(s.pending) >= 1/(2*s.epsilon) {
+		s.compress()
+	}
+}
+
+// Quantile returns a value whose rank is within Epsilon·Count of q·Count, for q in [0, 1].
+// Quantile(0.5) is an approximate median. It panics if the sketch is empty.
+func (s *Sketch[O]) Quantile(q float64) O {
+	if len(s.tuples) == 0 {
+		panic("quantile of an empty sketch")
+	}
+	q = math.Max(0, math.Min(1, q))
+	rank := math.Ceil(q * float64(s.count))
+	bound := s.epsilon * float64(s.count)
+
+	rmin := 0
+	for i, t := range s.tuples {
+		rmin += t.G
+		if float64(rmin+t.Delta) > rank+bound && i > 0 {
+			return s.tuples[i-1].Value
+		}
+	}
+	return s.tuples[len(s.tuples)-1].Value
+}
+
+// Rank returns an estimate of the number of values less than or equal to value,
+// within Epsilon·Count of the exact count.
+func (s *Sketch[O]) Rank(value O) int {
+	rmin := 0
+	for _, t := range s.tuples {
+		if functions.Less(value, t.Value) {
+			// The exact rank lies between rmin and this tuple's maximal rank minus one.
+			return rmin + (t.G+t.Delta-1)/2
+		}
+		rmin += t.G
+	}
+	return rmin
+}
+
+// Merge adds every value summarized by other to the sketch.
+// The merged sketch keeps the larger of both epsilons. other is not modified.
+func (s *Sketch[O]) Merge(other *Sketch[O]) {
+	if other.epsilon > s.epsilon {
+		s.epsilon = other.epsilon
+	}
+	merged := make([]sketchTuple[O], 0, len(s.tuples)+len(other.tuples))
+	i, j := 0, 0
+	for i < len(s.tuples) || j < len(other.tuples) {
+		// A tuple taken from one summary gains the rank uncertainty of
+		// the next tuple of the other summary.
+		if j == len(other.tuples) || i < len(s.tuples) && !functions.Less(other.tuples[j].Value, s.tuples[i].Value) {
+			t := s.tuples[i]
+			if j < len(other.tuples) {
+				t.Delta += other.tuples[j].G + other.tuples[j].Delta - 1
+			}
+			merged = append(merged, t)
+			i++
+		} else {
+			t := other.tuples[j]
+			if i < len(s.tuples) {
+				t.Delta += s.tuples[i].G + s.tuples[i].Delta - 1
+			}
+			merged = append(merged, t)
+			j++
+		}
+	}
+	s.tuples = merged
+	s.count += other.count
+	s.compress()
+}
+
+// Len returns the number of values kept by the sketch, which is the memory it uses.
+func (s *Sketch[O]) Len() int { return len(s.tuples) }
+
+// compress merges adjacent tuples whose combined rank uncertainty stays under 2·ε·n.
+func (s *Sketch[O]) compress() {
+	s.pending = 0
+	threshold := int(2 * s.epsilon * float64(s.count))
+	if len(s.tuples) < 3 {
+		return
+	}
+	// The first and last tuples hold the exact minimum and maximum and are never merged away.
+	kept := []sketchTuple[O]{s.tuples[len(s.tuples)-1]}
+	for i := len(s.tuples) - 2; i >= 1; i-- {
+		t, next := s.tuples[i], &kept[len(kept)-1]
+		if t.G+next.G+next.Delta <= threshold {
+			next.G += t.G
+			continue
+		}
+		kept = append(kept, t)
+	}
+	kept = append(kept, s.tuples[0])
+	for l, r := 0, len(kept)-1; l < r; l, r = l+1, r-1 {
+		kept[l], kept[r] = kept[r], kept[l]
+	}
+	s.tuples = kept
+}
+
+// sketchState is the serialised form of a Sketch.
+type sketchState[O constraints.Ordered] struct {
+	Epsilon float64
+	Count   int
+	Tuples  []sketchTuple[O]
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (s *Sketch[O]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(sketchState[O]{s.epsilon, s.count, s.tuples})
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *Sketch[O]) UnmarshalBinary(data []byte) error {
+	var state sketchState[O]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&state); err != nil {
+		return err
+	}
+	if !(state.Epsilon > 0 && state.Epsilon < 1) {
+		return errors.New("ordered: invalid sketch epsilon")
+	}
+	*s = Sketch[O]{epsilon: state.Epsilon, count: state.Count, tuples: state.Tuples}
+	return nil
+}
@@ -0,0 +1,95 @@
+package ordered_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	. "github.com/cramanan/go-types/slices/ordered"
+)
+
+// checkSketch compares the sketch answers with the exact ranks of the sorted values.
+func checkSketch(t *testing.T, desc string, sketch *Sketch[float64], sorted Ordered[float64]) {
+	t.Helper()
+	n := float64(len(sorted))
+	bound := sketch.Epsilon()*n + 1
+
+	for q := 0.0; q <= 1; q += 0.05 {
+		v := sketch.Quantile(q)
+		// v occupies the exact ranks (LowerBound, UpperBound].
+		lo, hi := float64(sorted.LowerBound(v)+1), float64(sorted.UpperBound(v))
+		target := math.Ceil(q * n)
+		if target < lo-bound || target > hi+bound {
+			t.Errorf("%s: Quantile(%.2f) = %v with ranks [%v, %v], want rank %v ± %v", desc, q, v, lo, hi, target, bound)
+		}
+	}
+	for _, v := range []float64{sorted[0], sorted[len(sorted)/3], sorted[len(sorted)/2], sorted[len(sorted)-1]} {
+		exact := float64(sorted.UpperBound(v))
+		if got := float64(sketch.Rank(v)); math.Abs(got-exact) > bound {
+			t.Errorf("%s: Rank(%v) = %v, want %v ± %v", desc, v, got, exact, bound)
+		}
+	}
+}
+
+func TestSketch(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	const n = 20000
+
+	a, b := NewSketch[float64](0.01), NewSketch[float64](0.005)
+	all := make(Ordered[float64], 0, 2*n)
+	for i := 0; i < n; i++ {
+		x, y := r.NormFloat64(), r.ExpFloat64()
+		a.Insert(x)
+		b.Insert(y)
+		all = append(all, x, y)
+	}
+	sorted := all.Sort()
+
+	checkSketch(t, "Insert", a, all.Filter(func(_ float64, i int) bool { return i%2 == 0 }).Sort())
+	if a.Len() > n/10 {
+		t.Errorf("Sketch kept %d of %d values", a.Len(), n)
+	}
+
+	a.Merge(b)
+	if a.Count() != 2*n || a.Epsilon() != 0.01 {
+		t.Errorf("Merge() count = %d, epsilon = %v, want %d, %v", a.Count(), a.Epsilon(), 2*n, 0.01)
+	}
+	checkSketch(t, "Merge", a, sorted)
+
+	checkSketch(t, "SketchFrom", all.Sketch(0.02), sorted)
+
+	data, err := a.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := new(Sketch[float64])
+	if err = decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Count() != a.Count() || decoded.Quantile(0.5) != a.Quantile(0.5) {
+		t.Error("UnmarshalBinary() does not match the encoded sketch")
+	}
+}
+
+func TestSketchStrings(t *testing.T) {
+	s := NewSketch[string](0.1)
+	for _, v := range []string{"d", "a", "c", "b", "e"} {
+		s.Insert(v)
+	}
+	if got := s.Quantile(0); got != "a" {
+		t.Errorf("Quantile(0) = %q, want %q", got, "a")
+	}
+	if got := s.Quantile(1); got != "e" {
+		t.Errorf("Quantile(1) = %q, want %q", got, "e")
+	}
+	if got := s.Rank("c"); got != 3 {
+		t.Errorf("Rank(%q) = %d, want %d", "c", got, 3)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Quantile of an empty sketch should panic")
+		}
+	}()
+	NewSketch[string](0.1).Quantile(0.5)
+}
@@ -0,0 +1,189 @@
+package ordered
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"golang.org/x/exp/constraints"
+)
+
+// ErrLengthMismatch is returned by the element-wise operations when both slices do not have the same length.
+var ErrLengthMismatch = errors.New("ordered: slices have different lengths")
+
+// Add returns the element-wise sum a[i] + b[i].
+// It returns an error wrapping ErrLengthMismatch if a and b have different lengths.
+func Add[S ~[]N, N Number](a, b S) (S, error) {
+	if err := checkLengths(len(a), len(b)); err != nil {
+		return nil, err
+	}
+	sum := make(S, len(a))
+	b = b[:len(a)]
+	for i, v := range a {
+		sum[i] = v + b[i]
+	}
+	return sum, nil
+}
+
+// Sub returns the element-wise difference a[i] - b[i].
+// It returns an error wrapping ErrLengthMismatch if a and b have different lengths.
+func Sub[S ~[]N, N Number](a, b S) (S, error) {
+	if err := checkLengths(len(a), len(b)); err != nil {
+		return nil, err
+	}
+	diff := make(S, len(a))
+	b = b[:len(a)]
+	for i, v := range a {
+		diff[i] = v - b[i]
+	}
+	return diff, nil
+}
+
+// Mul returns the element-wise product a[i] * b[i].
+// It returns an error wrapping ErrLengthMismatch if a and b have different lengths.
+func Mul[S ~[]N, N Number](a, b S) (S, error) {
+	if err := checkLengths(len(a), len(b)); err != nil {
+		return nil, err
+	}
+	product := make(S, len(a))
+	b = b[:len(a)]
+	for i, v := range a {
+		product[i] = v * b[i]
+	}
+	return product, nil
+}
+
+// Div returns the element-wise quotient a[i] / b[i].
+// It returns an error wrapping ErrLengthMismatch if a and b have different lengths.
+// Like the / operator, an integer division by zero panics.
+func Div[S ~[]N, N Number](a, b S) (S, error) {
+	if err := checkLengths(len(a), len(b)); err != nil {
+		return nil, err
+	}
+	quotient := make(S, len(a))
+	b = b[:len(a)]
+	for i, v := range a {
+		quotient[i] = v / b[i]
+	}
+	return quotient, nil
+}
+
+// Scale returns a new slice with every element of s multiplied by factor.
+func Scale[S ~[]N, N Number](s S, factor N) S {
+	scaled := make(S, len(s))
+	for i, v := range s {
+		scaled[i] = v * factor
+	}
+	return scaled
+}
+
+// Dot returns the dot product of a and b, the sum of a[i] * b[i].
+// It returns an error wrapping ErrLengthMismatch if a and b have different lengths.
+func Dot[S ~[]N, N Number](a, b S) (N, error) {
+	if err := checkLengths(len(a), len(b)); err != nil {
+		return 0, err
+	}
+	var dot N
+	b = b[:len(a)]
+	for i, v := range a {
+		dot += v * b[i]
+	}
+	return dot, nil
+}
+
+// Norm returns the Euclidean norm of s, the square root of the sum of its squared elements.
+func Norm[S ~[]N, N Number](s S) float64 {
+	var sum float64
+	for _, v := range s {
+		sum += float64(v) * float64(v)
+	}
+	return math.Sqrt(sum)
+}
+
+// Abs returns a new slice holding the absolute value of every element of s.
+func Abs[S ~[]N, N Number](s S) S {
+	abs := make(S, len(s))
+	for i, v := range s {
+		if v < 0 {
+			v = -v
+		}
+		abs[i] = v
+	}
+	return abs
+}
+
+// Round returns a new slice with every element of s rounded to the nearest integer,
+// rounding half away from zero. Integer slices are returned as a copy.
+func Round[S ~[]N, N Number](s S) S {
+	rounded := make(S, len(s))
+	if N(1)/N(2) == 0 {
+		copy(rounded, s)
+		return rounded
+	}
+	for i, v := range s {
+		rounded[i] = N(math.Round(float64(v)))
+	}
+	return rounded
+}
+
+// Clamp returns a new slice where every element of s is limited to the range [lo, hi].
+// It panics if lo is greater than hi.
+func Clamp[S ~[]O, O constraints.Ordered](s S, lo, hi O) S {
+	if hi < lo {
+		panic("clamp lower bound is greater than upper bound")
+	}
+	clamped := make(S, len(s))
+	for i, v := range s {
+		if v < lo {
+			v = lo
+		} else if v > hi {
+			v = hi
+		}
+		clamped[i] = v
+	}
+	return clamped
+}
+
+// ArgMin returns the index of the first minimal element of s, or -1 if s is empty.
+// For floating-point types, NaNs are ignored unless every element is NaN.
+func ArgMin[S ~[]O, O constraints.Ordered](s S) int {
+	return argExtremum(s, func(a, b O) bool { return a < b })
+}
+
+// ArgMax returns the index of the first maximal element of s, or -1 if s is empty.
+// For floating-point types, NaNs are ignored unless every element is NaN.
+func ArgMax[S ~[]O, O constraints.Ordered](s S) int {
+	return argExtremum(s, func(a, b O) bool { return a > b })
+}
+
+// Clamp returns a new slice where every element is limited to the range [lo, hi]. See [Clamp].
+func (s Ordered[O]) Clamp(lo, hi O) Ordered[O] { return Clamp(s, lo, hi) }
+
+// ArgMin returns the index of the first minimal element, or -1 if the slice is empty. See [ArgMin].
+func (s Ordered[O]) ArgMin() int { return ArgMin(s) }
+
+// ArgMax returns the index of the first maximal element, or -1 if the slice is empty. See [ArgMax].
+func (s Ordered[O]) ArgMax() int { return ArgMax(s) }
+
+// argExtremum returns the index of the first element for which better holds against every other one.
+func argExtremum[S ~[]O, O constraints.Ordered](s S, better func(a, b O) bool) int {
+	if len(s) == 0 {
+		return -1
+	}
+	best := 0
+	for i, v := range s {
+		// A NaN best is replaced by the first non-NaN value.
+		if better(v, s[best]) || (s[best] != s[best] && v == v) {
+			best = i
+		}
+	}
+	return best
+}
+
+// checkLengths returns an error wrapping ErrLengthMismatch if both lengths differ.
+func checkLengths(a, b int) error {
+	if a != b {
+		return fmt.Errorf("%w: %d and %d", ErrLengthMismatch, a, b)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package ordered_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	. "github.com/cramanan/go-types/slices/ordered"
+)
+
+func TestVectorOperations(t *testing.T) {
+	a, b := New(1, 2, 3), New(4, 5, 6)
+
+	must := func(s Ordered[int], err error) Ordered[int] {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	testCases := []struct {
+		desc      string
+		got, want Ordered[int]
+	}{
+		{"Add", must(Add(a, b)), New(5, 7, 9)},
+		{"Sub", must(Sub(a, b)), New(-3, -3, -3)},
+		{"Mul", must(Mul(a, b)), New(4, 10, 18)},
+		{"Div", must(Div(b, a)), New(4, 2, 2)},
+		{"Scale", Scale(a, 3), New(3, 6, 9)},
+		{"Abs", Abs(New(-1, 0, 2)), New(1, 0, 2)},
+		{"Round int", Round(a), a},
+		{"Clamp", New(-5, 3, 12).Clamp(0, 10), New(0, 3, 10)},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !eq(tC.got, tC.want) {
+				t.Errorf("%s = %v, want %v", tC.desc, tC.got, tC.want)
+			}
+		})
+	}
+
+	if dot, err := Dot(a, b); err != nil || dot != 32 {
+		t.Errorf("Dot() = %d, %v, want %d", dot, err, 32)
+	}
+	if norm := Norm(New(3.0, 4)); norm != 5 {
+		t.Errorf("Norm() = %v, want %v", norm, 5)
+	}
+	if got, want := Round(New(1.5, -1.5, 2.4)), New(2.0, -2, 2); !eq(got, want) {
+		t.Errorf("Round() = %v, want %v", got, want)
+	}
+
+	if _, err := Add(a, New(1)); !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("Add() error = %v, want %v", err, ErrLengthMismatch)
+	}
+	if _, err := Dot(a, nil); !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("Dot() error = %v, want %v", err, ErrLengthMismatch)
+	}
+}
+
+func TestArgMinMax(t *testing.T) {
+	s := New(3.0, math.NaN(), 1, 7, 1, 7)
+	if got := s.ArgMin(); got != 2 {
+		t.Errorf("ArgMin() = %d, want %d", got, 2)
+	}
+	if got := s.ArgMax(); got != 3 {
+		t.Errorf("ArgMax() = %d, want %d", got, 3)
+	}
+	if got := ArgMax(New(math.NaN(), 2)); got != 1 {
+		t.Errorf("ArgMax(NaN first) = %d, want %d", got, 1)
+	}
+	if got := ArgMin(New[int]()); got != -1 {
+		t.Errorf("ArgMin(empty) = %d, want %d", got, -1)
+	}
+}
+
+var dotSink float64
+
+func BenchmarkDot(b *testing.B) {
+	x, y := make(Ordered[float64], 4096), make(Ordered[float64], 4096)
+	for i := range x {
+		x[i], y[i] = float64(i), float64(len(y)-i)
+	}
+	b.Run("Dot", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			dotSink, _ = Dot(x, y)
+		}
+	})
+	b.Run("Loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var dot float64
+			for j := range x {
+				dot += x[j] * y[j]
+			}
+			dotSink = dot
+		}
+	})
+}
+
+func BenchmarkAdd(b *testing.B) {
+	x, y := make(Ordered[int32], 4096), make(Ordered[int32], 4096)
+	out := make(Ordered[int32], len(x))
+	b.Run("Add", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			out, _ = Add(x, y)
+		}
+	})
+	b.Run("Loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sum := make(Ordered[int32], len(x))
+			for j := range x {
+				sum[j] = x[j] + y[j]
+			}
+			out = sum
+		}
+	})
+	_ = out
+}
@@ -0,0 +1,150 @@
+package ordered
+
+import "golang.org/x/exp/constraints"
+
+// MovingSum returns the sums of every window of consecutive elements of s.
+// The result has len(s)-window+1 elements, the i-th being the sum of s[i:i+window],
+// and is nil if s is shorter than window. It panics if window is not positive.
+// MovingSum runs in O(n) and never subtracts, so a large value, a NaN or an infinity leaving the window
+// does not affect the next sums.
+func MovingSum[S ~[]N, N Number](s S, window int) S {
+	checkWindow(window)
+	if len(s) < window {
+		return nil
+	}
+	sums := make(S, len(s)-window+1)
+	// The window is a queue of two stacks: the front s[start:boundary] holds suffix sums,
+	// indexed modulo window, and the back s[boundary:i+1] holds the running sum back.
+	// When the front is empty, the back is moved into it.
+	suffix := make(S, window)
+	boundary := 0
+	var back N
+	for i, v := range s {
+		back += v
+		start := i - window + 1
+		if start < 0 {
+			continue
+		}
+		if start >= boundary {
+			var sum N
+			for k := i; k >= start; k-- {
+				sum += s[k]
+				suffix[k%window] = sum
+			}
+			boundary, back = i+1, 0
+		}
+		sums[start] = suffix[start%window] + back
+	}
+	return sums
+}
+
+// MovingAverage returns the means of every window of consecutive elements of s.
+// It works like [MovingSum]; for integer types the means are truncated.
+func MovingAverage[S ~[]N, N Number](s S, window int) S {
+	averages := MovingSum(s, window)
+	for i := range averages {
+		averages[i] /= N(window)
+	}
+	return averages
+}
+
+// MovingMin returns the minimum of every window of consecutive elements of s.
+// It works like [MovingSum] and runs in O(n) using a monotonic deque.
+func MovingMin[S ~[]O, O constraints.Ordered](s S, window int) S {
+	return movingExtremum(s, window, func(a, b O) bool { return a <= b })
+}
+
+// MovingMax returns the maximum of every window of consecutive elements of s.
+// It works like [MovingSum] and runs in O(n) using a monotonic deque.
+func MovingMax[S ~[]O, O constraints.Ordered](s S, window int) S {
+	return movingExtremum(s, window, func(a, b O) bool { return a >= b })
+}
+
+// ExponentialMovingAverage returns the exponentially weighted moving average of s:
+// the first element is s[0] and each next one is alpha·s[i] + (1-alpha)·previous.
+// The average is computed in float64 and converted to the element type.
+// It panics if alpha is not in (0, 1].
+func ExponentialMovingAverage[S ~[]N, N Number](s S, alpha float64) S {
+	if !(alpha > 0 && alpha <= 1) {
+		panic("alpha must be between 0 and 1")
+	}
+	if len(s) == 0 {
+		return nil
+	}
+	averages := make(S, len(s))
+	average := float64(s[0])
+	for i, v := range s {
+		if i > 0 {
+			average = alpha*float64(v) + (1-alpha)*average
+		}
+		averages[i] = N(average)
+	}
+	return averages
+}
+
+// Diff returns the differences between consecutive elements of s: s[i+1] - s[i].
+// The result has len(s)-1 elements and is nil if s has fewer than two elements.
+func Diff[S ~[]N, N Number](s S) S {
+	if len(s) < 2 {
+		return nil
+	}
+	diffs := make(S, len(s)-1)
+	for i := range diffs {
+		diffs[i] = s[i+1] - s[i]
+	}
+	return diffs
+}
+
+// PctChange returns the relative changes between consecutive elements of s: (s[i+1] - s[i]) / s[i].
+// A change from 100 to 110 is 0.1. The result has len(s)-1 elements and is nil if s has fewer than two elements.
+//
+// The changes are computed in float64 and converted to the element type,
+// so integer types truncate them; use a floating-point slice for fractional changes.
+// For floating-point types, a change from zero is ±Inf or NaN.
+func PctChange[S ~[]N, N Number](s S) S {
+	if len(s) < 2 {
+		return nil
+	}
+	changes := make(S, len(s)-1)
+	for i := range changes {
+		changes[i] = N((float64(s[i+1]) - float64(s[i])) / float64(s[i]))
+	}
+	return changes
+}
+
+// MovingMin returns the minimum of every window of consecutive elements. See [MovingMin].
+func (s Ordered[O]) MovingMin(window int) Ordered[O] { return MovingMin(s, window) }
+
+// MovingMax returns the maximum of every window of consecutive elements. See [MovingMax].
+func (s Ordered[O]) MovingMax(window int) Ordered[O] { return MovingMax(s, window) }
+
+// movingExtremum keeps a deque of indices whose values are ordered by keep,
+// so the front of the deque is always the extremum of the current window.
+func movingExtremum[S ~[]O, O constraints.Ordered](s S, window int, keep func(a, b O) bool) S {
+	checkWindow(window)
+	if len(s) < window {
+		return nil
+	}
+	result := make(S, len(s)-window+1)
+	deque := make([]int, 0, window)
+	for i, v := range s {
+		if len(deque) > 0 && deque[0] <= i-window {
+			deque = deque[1:]
+		}
+		for len(deque) > 0 && !keep(s[deque[len(deque)-1]], v) {
+			deque = deque[:len(deque)-1]
+		}
+		deque = append(deque, i)
+		if i >= window-1 {
+			result[i-window+1] = s[deque[0]]
+		}
+	}
+	return result
+}
+
+// checkWindow panics if window is not positive.
+func checkWindow(window int) {
+	if window <= 0 {
+		panic("window must be positive")
+	}
+}
@@ -0,0 +1,100 @@
+package ordered_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/cramanan/go-types/slices/ordered"
+)
+
+func TestMovingWindows(t *testing.T) {
+	s := New(1, 3, 2, 5, 4, 0)
+
+	testCases := []struct {
+		desc      string
+		got, want Ordered[int]
+	}{
+		{"MovingSum", MovingSum(s, 3), New(6, 10, 11, 9)},
+		{"MovingSum window 1", MovingSum(s, 1), s},
+		{"MovingSum too short", MovingSum(s, 7), nil},
+		{"MovingAverage", MovingAverage(s, 2), New(2, 2, 3, 4, 2)},
+		{"MovingMin", s.MovingMin(3), New(1, 2, 2, 0)},
+		{"MovingMax", s.MovingMax(3), New(3, 5, 5, 5)},
+		{"MovingMax window len", MovingMax(s, len(s)), New(5)},
+		{"Diff", Diff(s), New(2, -1, 3, -1, -4)},
+		{"Diff single", Diff(New(1)), nil},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !eq(tC.got, tC.want) {
+				t.Errorf("%s = %v, want %v", tC.desc, tC.got, tC.want)
+			}
+		})
+	}
+
+	strs := New("b", "a", "c", "c", "a")
+	if got, want := strs.MovingMin(2), New("a", "a", "c", "a"); !eq(got, want) {
+		t.Errorf("MovingMin(strings) = %v, want %v", got, want)
+	}
+
+	defer func() {
+		if reason := recover(); reason != "window must be positive" {
+			t.Error("Should have panicked but didn't")
+		}
+	}()
+	MovingSum(s, 0)
+}
+
+func TestMovingSumFloats(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	testCases := []struct {
+		desc      string
+		got, want Ordered[float64]
+	}{
+		{"NaN", MovingSum(New(nan, 1, 2, 3), 1), New(nan, 1, 2, 3)},
+		{"NaN leaves the window", MovingSum(New(1, nan, 2, 3, 4), 2), New(nan, nan, 5, 7)},
+		{"Inf", MovingSum(New(inf, 1, 2, 3), 2), New(inf, 3, 5)},
+		{"Inf and -Inf", MovingSum(New(inf, -inf, 1, 2), 2), New(nan, -inf, 3)},
+		{"MovingAverage", MovingAverage(New(inf, 1, 3), 2), New(inf, 2)},
+		{"cancellation", MovingSum(New(1e20, 1, 1, 1), 2), New(1e20, 2, 2)},
+		{"MovingAverage cancellation", MovingAverage(New(1e20, 1, 1, 1), 2), New(5e19, 1, 1)},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if len(tC.got) != len(tC.want) {
+				t.Fatalf("%s = %v, want %v", tC.desc, tC.got, tC.want)
+			}
+			for i := range tC.got {
+				if tC.got[i] != tC.want[i] && !(math.IsNaN(tC.got[i]) && math.IsNaN(tC.want[i])) {
+					t.Errorf("%s = %v, want %v", tC.desc, tC.got, tC.want)
+				}
+			}
+		})
+	}
+}
+
+func TestExponentialMovingAverage(t *testing.T) {
+	got := ExponentialMovingAverage(New(10.0, 20, 20, 0), 0.5)
+	if want := New(10.0, 15, 17.5, 8.75); !eq(got, want) {
+		t.Errorf("ExponentialMovingAverage() = %v, want %v", got, want)
+	}
+
+	changes := PctChange(New(100.0, 110, 99, 0, 5))
+	want := New(0.1, -0.1, -1, math.Inf(1))
+	for i := range want {
+		if math.Abs(changes[i]-want[i]) > 1e-12 && changes[i] != want[i] {
+			t.Errorf("PctChange()[%d] = %v, want %v", i, changes[i], want[i])
+		}
+	}
+}
+
+func BenchmarkMovingMax(b *testing.B) {
+	s := make(Ordered[float64], 1<<16)
+	for i := range s {
+		s[i] = math.Sin(float64(i))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MovingMax(s, 100)
+	}
+}
@@ -0,0 +1,122 @@
+package slices
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/cramanan/go-types/functions"
+	"golang.org/x/exp/constraints"
+)
+
+// parallelThreshold is the minimum number of elements per goroutine in a parallel sort.
+// Slices shorter than twice this length are sorted on the calling goroutine.
+const parallelThreshold = 1 << 12
+
+// ParallelSort sorts the slice s in ascending order, splitting the work across
+// up to GOMAXPROCS goroutines. See [ParallelSortFunc].
+//
+// For floating-point types, a NaN is considered less than any non-NaN.
+func ParallelSort[S ~[]E, E constraints.Ordered](s S) {
+	parallelSort(s, functions.Compare[E], Sort[S, E])
+}
+
+// ParallelSortFunc sorts the slice s in ascending order as determined by the cmp function.
+// This sort is not guaranteed to be stable.
+//
+// The slice is split into up to GOMAXPROCS chunks that are sorted concurrently with [SortFunc],
+// then merged pairwise. Small slices are sorted with [SortFunc] directly.
+// cmp must be safe for concurrent use.
+func ParallelSortFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	if cmp == nil {
+		panic("callback function is nil")
+	}
+	parallelSort(s, cmp, func(chunk S) { SortFunc(chunk, cmp) })
+}
+
+// ParallelSortStableFunc is like [ParallelSortFunc] but keeps the original order of equal elements,
+// using [SortStableFunc] on each chunk and stable merges.
+func ParallelSortStableFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	if cmp == nil {
+		panic("callback function is nil")
+	}
+	parallelSort(s, cmp, func(chunk S) { SortStableFunc(chunk, cmp) })
+}
+
+// ParallelSortFunc sorts the slice in place using concurrent goroutines.
+// See [ParallelSortFunc].
+func (s Slice[T]) ParallelSortFunc(cmp func(a, b T) int) { ParallelSortFunc(s, cmp) }
+
+// ParallelSortStableFunc sorts the slice in place using concurrent goroutines,
+// keeping the original order of equal elements. See [ParallelSortStableFunc].
+func (s Slice[T]) ParallelSortStableFunc(cmp func(a, b T) int) { ParallelSortStableFunc(s, cmp) }
+
+// parallelSort sorts chunks of s concurrently with sort, then merges them using cmp.
+func parallelSort[S ~[]E, E any](s S, cmp func(a, b E) int, sort func(S)) {
+	chunks := runtime.GOMAXPROCS(0)
+	if limit := len(s) / parallelThreshold; chunks > limit {
+		chunks = limit
+	}
+	if chunks < 2 {
+		sort(s)
+		return
+	}
+
+	bounds := make([]int, chunks+1)
+	for i := range bounds {
+		bounds[i] = i * len(s) / chunks
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < chunks; i++ {
+		wg.Add(1)
+		go func(chunk S) {
+			defer wg.Done()
+			sort(chunk)
+		}(s[bounds[i]:bounds[i+1]])
+	}
+	wg.Wait()
+
+	src, dst := s, make(S, len(s))
+	for len(bounds) > 2 {
+		merged := make([]int, 0, len(bounds)/2+1)
+		for i := 0; i+1 < len(bounds); i += 2 {
+			lo := bounds[i]
+			merged = append(merged, lo)
+			if i+2 >= len(bounds) {
+				// Odd run out: carry it over unchanged.
+				copy(dst[lo:], src[lo:bounds[i+1]])
+				continue
+			}
+			mid, hi := bounds[i+1], bounds[i+2]
+			wg.Add(1)
+			go func(lo, mid, hi int) {
+				defer wg.Done()
+				merge(dst[lo:hi], src[lo:mid], src[mid:hi], cmp)
+			}(lo, mid, hi)
+		}
+		wg.Wait()
+		bounds = append(merged, len(s))
+		src, dst = dst, src
+	}
+	if &src[0] != &s[0] {
+		copy(s, src)
+	}
+}
+
+// merge merges the sorted slices left and right into dst.
+// On ties, elements of left come first so the merge is stable.
+func merge[S ~[]E, E any](dst, left, right S, cmp func(a, b E) int) {
+	i, j, k := 0, 0, 0
+	for i < len(left) && j < len(right) {
+		if cmp(right[j], left[i]) < 0 {
+			dst[k] = right[j]
+			j++
+		} else {
+			dst[k] = left[i]
+			i++
+		}
+		k++
+	}
+	k += copy(dst[k:], left[i:])
+	copy(dst[k:], right[j:])
+}
@@ -0,0 +1,75 @@
+package slices_test
+
+import (
+	"math/rand"
+	"runtime"
+	"sort"
+	"testing"
+
+	. "github.com/cramanan/go-types/slices"
+)
+
+func TestParallelSort(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(5))
+
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 10, 1 << 12, 50000} {
+		s := make(Slice[int], n)
+		for i := range s {
+			s[i] = r.Intn(1000)
+		}
+		want := s.Clone()
+		sort.Ints(want)
+
+		got := s.Clone()
+		ParallelSort(got)
+		if !Equal(got, want) {
+			t.Errorf("ParallelSort(%d elements) is not sorted", n)
+		}
+
+		got = s.Clone()
+		got.ParallelSortFunc(func(a, b int) int { return b - a })
+		sort.Sort(sort.Reverse(sort.IntSlice(want)))
+		if !Equal(got, want) {
+			t.Errorf("ParallelSortFunc(%d elements) is not sorted", n)
+		}
+	}
+}
+
+func TestParallelSortStable(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(3))
+
+	r := rand.New(rand.NewSource(2))
+	s := make(Slice[record], 40000)
+	for i := range s {
+		s[i] = record{Name: string(rune('a' + i%26)), Rank: r.Intn(50)}
+	}
+	want := s.Clone()
+	sort.SliceStable(want, func(i, j int) bool { return want[i].Rank < want[j].Rank })
+
+	s.ParallelSortStableFunc(func(a, b record) int { return a.Rank - b.Rank })
+	if !Equal(s, want) {
+		t.Error("ParallelSortStableFunc() did not keep the order of equal elements")
+	}
+}
+
+func BenchmarkParallelSort(b *testing.B) {
+	r := rand.New(rand.NewSource(3))
+	s := make(Slice[float64], 1<<20)
+	for i := range s {
+		s[i] = r.Float64()
+	}
+	work := make(Slice[float64], len(s))
+	b.Run("Parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(work, s)
+			ParallelSort(work)
+		}
+	})
+	b.Run("Sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(work, s)
+			Sort(work)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package slices
+
+import (
+	"unsafe"
+
+	"golang.org/x/exp/constraints"
+)
+
+// RadixSortBy sorts the slice s in place in ascending order of the integer key returned by keyFn,
+// using a stable LSD radix sort: elements with equal keys keep their original order.
+//
+// keyFn is called exactly once per element. RadixSortBy runs in O(n·w) time,
+// where w is the size of the key type in bytes, and allocates two buffers of len(s) records.
+//
+// Example:
+//
+//	type Event struct {
+//		At   int64
+//		Name string
+//	}
+//
+//	RadixSortBy(events, func(e Event) int64 { return e.At })
+func RadixSortBy[S ~[]E, E any, K constraints.Integer](s S, keyFn func(E) K) {
+	if keyFn == nil {
+		panic("callback function is nil")
+	}
+	if len(s) < 2 {
+		return
+	}
+
+	size := int(unsafe.Sizeof(K(0)))
+	mask, flip := ^uint64(0), uint64(0)
+	if size < 8 {
+		mask = 1<<(8*size) - 1
+	}
+	if ^K(0) < 0 {
+		flip = 1 << (8*size - 1)
+	}
+
+	type record struct {
+		key     uint64
+		element E
+	}
+	records := make([]record, len(s))
+	for i, element := range s {
+		records[i] = record{uint64(keyFn(element))&mask ^ flip, element}
+	}
+
+	buf := make([]record, len(records))
+	for shift := 0; shift < 8*size; shift += 8 {
+		var count [256]int
+		for _, r := range records {
+			count[byte(r.key>>shift)]++
+		}
+		if count[byte(records[0].key>>shift)] == len(records) {
+			continue
+		}
+		offset := 0
+		for b, c := range count {
+			count[b] = offset
+			offset += c
+		}
+		for _, r := range records {
+			b := byte(r.key >> shift)
+			buf[count[b]] = r
+			count[b]++
+		}
+		records, buf = buf, records
+	}
+
+	for i, r := range records {
+		s[i] = r.element
+	}
+}
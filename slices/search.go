@@ -0,0 +1,31 @@
+package slices
+
+import (
+	"github.com/cramanan/go-types/functions"
+	"golang.org/x/exp/constraints"
+)
+
+// SearchBy searches for key in a slice sorted in increasing order of keyFn(element)
+// and returns the position where key is found, or the position where key would appear
+// in the sort order; it also returns a bool saying whether the key is really found.
+//
+// It works like [BinarySearchFunc] without having to write a comparison closure
+// for the projected field.
+//
+// Example:
+//
+//	type User struct {
+//		ID   int
+//		Name string
+//	}
+//
+//	users := Slice[User]{{1, "Alice"}, {4, "Bob"}, {7, "Jane"}}
+//	SearchBy(users, 4, func(u User) int { return u.ID }) // returns 1, true
+func SearchBy[S ~[]E, E any, K constraints.Ordered](s S, key K, keyFn func(E) K) (int, bool) {
+	if keyFn == nil {
+		panic("callback function is nil")
+	}
+	return BinarySearchFunc(s, key, func(element E, target K) int {
+		return functions.Compare(keyFn(element), target)
+	})
+}
@@ -0,0 +1,37 @@
+package slices_test
+
+import (
+	"testing"
+
+	. "github.com/cramanan/go-types/slices"
+)
+
+func TestSearchBy(t *testing.T) {
+	type User struct {
+		ID   int
+		Name string
+	}
+	users := Slice[User]{{1, "Alice"}, {4, "Bob"}, {7, "Jane"}}
+	id := func(u User) int { return u.ID }
+
+	testCases := []struct {
+		key   int
+		pos   int
+		found bool
+	}{
+		{1, 0, true},
+		{4, 1, true},
+		{5, 2, false},
+		{9, 3, false},
+	}
+	for _, tC := range testCases {
+		pos, found := SearchBy(users, tC.key, id)
+		if pos != tC.pos || found != tC.found {
+			t.Errorf("SearchBy(%d) = %d, %t, want %d, %t", tC.key, pos, found, tC.pos, tC.found)
+		}
+	}
+
+	if !panics(func() { SearchBy[Slice[User], User, int](users, 1, nil) }) {
+		t.Error("SearchBy with nil keyFn should panic")
+	}
+}
@@ -14,8 +14,12 @@ func Map[SI ~[]I, I, O any](s SI, callbackFn func(I, int) O) (mapped []O) {
 	if callbackFn == nil {
 		panic("callback function is nil")
 	}
+	if len(s) == 0 {
+		return nil
+	}
+	mapped = make([]O, len(s))
 	for i, v := range s {
-		mapped = append(mapped, callbackFn(v, i))
+		mapped[i] = callbackFn(v, i)
 	}
 	return mapped
 }
@@ -231,7 +235,7 @@ func MaxFunc[S ~[]E, E any](x S, cmp func(a, b E) int) E { return MaxFunc(x, cmp
 // cmp must implement the same ordering as the slice, such that if
 // cmp(a, t) < 0 and cmp(b, t) >= 0, then a must precede b in the slice.
 func BinarySearchFunc[S ~[]E, E, T any](x S, target T, cmp func(E, T) int) (int, bool) {
-	return BinarySearchFunc(x, target, cmp)
+	return slices.BinarySearchFunc(x, target, cmp)
 }
 
 // BinarySearch searches for target in a sorted slice and returns the position
@@ -442,8 +446,12 @@ func (slice Slice[T]) Map(callbackFn func(T, int) T) (mapped Slice[T]) {
 	if callbackFn == nil {
 		panic("callback function is nil")
 	}
+	if len(slice) == 0 {
+		return nil
+	}
+	mapped = make(Slice[T], len(slice))
 	for i, v := range slice {
-		mapped = append(mapped, callbackFn(v, i))
+		mapped[i] = callbackFn(v, i)
 	}
 	return mapped
 }
@@ -457,6 +465,9 @@ func (slice Slice[T]) Filter(callbackFn func(element T, index int) bool) (filter
 	}
 	for idx, value := range slice {
 		if callbackFn(value, idx) {
+			if filtered == nil {
+				filtered = make(Slice[T], 0, len(slice)-idx)
+			}
 			filtered = append(filtered, value)
 		}
 	}
@@ -549,10 +560,14 @@ func (s Slice[T]) CountFunc(target T, callbackFn func(T, T) bool) (count int) {
 
 // Fill returns a new Slice where all elements are replaced with the given value.
 //
-// The Fill method creates a new slice and appends the given value for each element in the original slice.
+// The Fill method creates a new slice of the same length and sets the given value for each element.
 func (s Slice[T]) Fill(value T) (copy Slice[T]) {
-	for range s {
-		copy = append(copy, value)
+	if len(s) == 0 {
+		return nil
+	}
+	copy = make(Slice[T], len(s))
+	for i := range copy {
+		copy[i] = value
 	}
 	return copy
 }
@@ -0,0 +1,78 @@
+package slices
+
+import (
+	"github.com/cramanan/go-types/functions"
+	"golang.org/x/exp/constraints"
+)
+
+// keyed pairs an element with its precomputed sort key.
+type keyed[E any, K constraints.Ordered] struct {
+	key     K
+	element E
+}
+
+// SortBy sorts the slice s in place in ascending order of keyFn(element).
+// This sort is not guaranteed to be stable.
+//
+// Unlike [SortFunc], keyFn is called exactly once per element:
+// the keys are computed up front and the elements are sorted by their cached key.
+// Prefer SortBy when computing the key is expensive (parsing, case folding...).
+//
+// Example:
+//
+//	words := Slice[string]{"banana", "Apple", "cherry"}
+//	SortBy(words, strings.ToLower) // words is now [Apple banana cherry]
+func SortBy[S ~[]E, E any, K constraints.Ordered](s S, keyFn func(E) K) {
+	sortBy(s, keyFn, functions.Ascending[K], SortFunc[[]keyed[E, K]])
+}
+
+// SortStableBy is like [SortBy] but keeps the original order of elements with equal keys.
+func SortStableBy[S ~[]E, E any, K constraints.Ordered](s S, keyFn func(E) K) {
+	sortBy(s, keyFn, functions.Ascending[K], SortStableFunc[[]keyed[E, K]])
+}
+
+// SortByDesc is like [SortBy] but sorts in descending order of keyFn(element).
+// This sort is not guaranteed to be stable.
+func SortByDesc[S ~[]E, E any, K constraints.Ordered](s S, keyFn func(E) K) {
+	sortBy(s, keyFn, functions.Descending[K], SortFunc[[]keyed[E, K]])
+}
+
+// IsSortedBy reports whether s is sorted in ascending order of keyFn(element).
+// keyFn is called at most once per element.
+func IsSortedBy[S ~[]E, E any, K constraints.Ordered](s S, keyFn func(E) K) bool {
+	if keyFn == nil {
+		panic("callback function is nil")
+	}
+	if len(s) == 0 {
+		return true
+	}
+	previous := keyFn(s[0])
+	for _, element := range s[1:] {
+		key := keyFn(element)
+		if functions.Less(key, previous) {
+			return false
+		}
+		previous = key
+	}
+	return true
+}
+
+// sortBy computes every key once, sorts the pairs with sort and cmp, then writes the elements back into s.
+func sortBy[S ~[]E, E any, K constraints.Ordered](
+	s S,
+	keyFn func(E) K,
+	cmp functions.ComparisonFunc[K],
+	sort func([]keyed[E, K], func(a, b keyed[E, K]) int),
+) {
+	if keyFn == nil {
+		panic("callback function is nil")
+	}
+	pairs := make([]keyed[E, K], len(s))
+	for i, element := range s {
+		pairs[i] = keyed[E, K]{keyFn(element), element}
+	}
+	sort(pairs, func(a, b keyed[E, K]) int { return cmp(a.key, b.key) })
+	for i, pair := range pairs {
+		s[i] = pair.element
+	}
+}
@@ -0,0 +1,87 @@
+package slices_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/cramanan/go-types/slices"
+)
+
+type record struct {
+	Name string
+	Rank int
+}
+
+func TestSortBy(t *testing.T) {
+	calls := 0
+	lower := func(s string) string {
+		calls++
+		return strings.ToLower(s)
+	}
+
+	words := Slice[string]{"banana", "Apple", "cherry", "apricot", "Blueberry"}
+	SortBy(words, lower)
+	if want := (Slice[string]{"Apple", "apricot", "banana", "Blueberry", "cherry"}); !Equal(words, want) {
+		t.Errorf("SortBy() = %v, want %v", words, want)
+	}
+	if calls != len(words) {
+		t.Errorf("SortBy() called keyFn %d times, want %d", calls, len(words))
+	}
+	if !IsSortedBy(words, lower) {
+		t.Errorf("IsSortedBy(%v) = false, want true", words)
+	}
+
+	SortByDesc(words, lower)
+	if want := (Slice[string]{"cherry", "Blueberry", "banana", "apricot", "Apple"}); !Equal(words, want) {
+		t.Errorf("SortByDesc() = %v, want %v", words, want)
+	}
+	if IsSortedBy(words, lower) {
+		t.Errorf("IsSortedBy(%v) = true, want false", words)
+	}
+}
+
+func TestSortStableBy(t *testing.T) {
+	records := Slice[record]{{"a", 2}, {"b", 1}, {"c", 2}, {"d", 1}, {"e", 0}}
+	SortStableBy(records, func(r record) int { return r.Rank })
+
+	want := Slice[record]{{"e", 0}, {"b", 1}, {"d", 1}, {"a", 2}, {"c", 2}}
+	if !Equal(records, want) {
+		t.Errorf("SortStableBy() = %v, want %v", records, want)
+	}
+}
+
+func BenchmarkSortBy(b *testing.B) {
+	words := make(Slice[string], 1000)
+	for i := range words {
+		words[i] = strings.Repeat(string(rune('A'+i%26)), 1+i%7)
+	}
+	s := make(Slice[string], len(words))
+	b.Run("SortBy", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(s, words)
+			SortBy(s, strings.ToLower)
+		}
+	})
+	b.Run("SortFunc", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(s, words)
+			s.SortFunc(func(x, y string) int { return strings.Compare(strings.ToLower(x), strings.ToLower(y)) })
+		}
+	})
+}
+
+func TestRadixSortBy(t *testing.T) {
+	records := Slice[record]{{"a", 3}, {"b", -1}, {"c", 3}, {"d", 0}, {"e", -1}, {"f", 1 << 40}}
+	RadixSortBy(records, func(r record) int { return r.Rank })
+
+	want := Slice[record]{{"b", -1}, {"e", -1}, {"d", 0}, {"a", 3}, {"c", 3}, {"f", 1 << 40}}
+	if !Equal(records, want) {
+		t.Errorf("RadixSortBy() = %v, want %v", records, want)
+	}
+
+	bytes := Slice[record]{{"x", 200}, {"y", 7}, {"z", 200}}
+	RadixSortBy(bytes, func(r record) uint8 { return uint8(r.Rank) })
+	if want := (Slice[record]{{"y", 7}, {"x", 200}, {"z", 200}}); !Equal(bytes, want) {
+		t.Errorf("RadixSortBy(uint8) = %v, want %v", bytes, want)
+	}
+}